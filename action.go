@@ -1,5 +1,10 @@
 package zabbix
 
+import (
+	"fmt"
+	"strconv"
+)
+
 // For `ActionObject` field: `Status`
 const (
 	ActionStatusEnabled  = 0
@@ -381,3 +386,129 @@ func (z *Context) ActionDelete(actionIDs []int) ([]int, int, error) {
 
 	return result.ActionIDs, status, nil
 }
+
+// ActionFilterConditionBuilder is used to build valid action filter conditions.
+// Builder methods can be chained, the first occurred error is returned by `Conditions()`,
+// e.g.:
+//
+//	var b ActionFilterConditionBuilder
+//	conditions, err := b.HostGroup(ActionFilterConditionOperatorEQ, 2).
+//		Severity(ActionFilterConditionOperatorGE, 3).
+//		Conditions()
+type ActionFilterConditionBuilder struct {
+	conditions []ActionFilterConditionObject
+	err        error
+}
+
+// Operators allowed for each condition type
+var actionFilterConditionOperators = map[int][]int{
+	ActionFilterConditionTypeHostroup:            {ActionFilterConditionOperatorEQ, ActionFilterConditionOperatorNE},
+	ActionFilterConditionTypeHost:                {ActionFilterConditionOperatorEQ, ActionFilterConditionOperatorNE},
+	ActionFilterConditionTypeTrigger:             {ActionFilterConditionOperatorEQ, ActionFilterConditionOperatorNE},
+	ActionFilterConditionTypeTriggerName:         {ActionFilterConditionOperatorContains, ActionFilterConditionOperatorNotrContain},
+	ActionFilterConditionTypeTriggerSeverity:     {ActionFilterConditionOperatorEQ, ActionFilterConditionOperatorNE, ActionFilterConditionOperatorGE, ActionFilterConditionOperatorLE},
+	ActionFilterConditionTypeTimePeriod:          {ActionFilterConditionOperatorIN, ActionFilterConditionOperatorNotIn},
+	ActionFilterConditionTypeHostTemplate:        {ActionFilterConditionOperatorEQ, ActionFilterConditionOperatorNE},
+	ActionFilterConditionTypeProblemIsSuppressed: {ActionFilterConditionOperatorYes, ActionFilterConditionOperatorNo},
+	ActionFilterConditionTypeTag:                 {ActionFilterConditionOperatorEQ, ActionFilterConditionOperatorNE, ActionFilterConditionOperatorContains, ActionFilterConditionOperatorNotrContain},
+	ActionFilterConditionTypeTagValue:            {ActionFilterConditionOperatorEQ, ActionFilterConditionOperatorNE, ActionFilterConditionOperatorContains, ActionFilterConditionOperatorNotrContain},
+}
+
+// HostGroup adds `host group` condition
+func (b *ActionFilterConditionBuilder) HostGroup(operator, groupID int) *ActionFilterConditionBuilder {
+	return b.add(ActionFilterConditionTypeHostroup, operator, strconv.Itoa(groupID), "")
+}
+
+// Host adds `host` condition
+func (b *ActionFilterConditionBuilder) Host(operator, hostID int) *ActionFilterConditionBuilder {
+	return b.add(ActionFilterConditionTypeHost, operator, strconv.Itoa(hostID), "")
+}
+
+// Trigger adds `trigger` condition
+func (b *ActionFilterConditionBuilder) Trigger(operator, triggerID int) *ActionFilterConditionBuilder {
+	return b.add(ActionFilterConditionTypeTrigger, operator, strconv.Itoa(triggerID), "")
+}
+
+// TriggerName adds `trigger name` condition
+func (b *ActionFilterConditionBuilder) TriggerName(operator int, name string) *ActionFilterConditionBuilder {
+	return b.add(ActionFilterConditionTypeTriggerName, operator, name, "")
+}
+
+// Severity adds `trigger severity` condition, severity must be within 0-5
+func (b *ActionFilterConditionBuilder) Severity(operator, severity int) *ActionFilterConditionBuilder {
+
+	if severity < 0 || severity > 5 {
+		return b.fail(fmt.Errorf("invalid severity %d, must be within 0-5", severity))
+	}
+
+	return b.add(ActionFilterConditionTypeTriggerSeverity, operator, strconv.Itoa(severity), "")
+}
+
+// TimePeriod adds `time period` condition, e.g. `1-5,09:00-18:00`
+func (b *ActionFilterConditionBuilder) TimePeriod(operator int, period string) *ActionFilterConditionBuilder {
+	return b.add(ActionFilterConditionTypeTimePeriod, operator, period, "")
+}
+
+// HostTemplate adds `host template` condition
+func (b *ActionFilterConditionBuilder) HostTemplate(operator, templateID int) *ActionFilterConditionBuilder {
+	return b.add(ActionFilterConditionTypeHostTemplate, operator, strconv.Itoa(templateID), "")
+}
+
+// ProblemIsSuppressed adds `problem is suppressed` condition
+func (b *ActionFilterConditionBuilder) ProblemIsSuppressed(operator int) *ActionFilterConditionBuilder {
+	return b.add(ActionFilterConditionTypeProblemIsSuppressed, operator, "", "")
+}
+
+// Tag adds `event tag` condition
+func (b *ActionFilterConditionBuilder) Tag(operator int, tag string) *ActionFilterConditionBuilder {
+	return b.add(ActionFilterConditionTypeTag, operator, tag, "")
+}
+
+// TagValue adds `event tag value` condition
+func (b *ActionFilterConditionBuilder) TagValue(operator int, tag, value string) *ActionFilterConditionBuilder {
+	return b.add(ActionFilterConditionTypeTagValue, operator, value, tag)
+}
+
+// Conditions returns built conditions or the first occurred error
+func (b *ActionFilterConditionBuilder) Conditions() ([]ActionFilterConditionObject, error) {
+
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	return b.conditions, nil
+}
+
+func (b *ActionFilterConditionBuilder) add(conditionType, operator int, value, value2 string) *ActionFilterConditionBuilder {
+
+	valid := false
+
+	for _, o := range actionFilterConditionOperators[conditionType] {
+		if o == operator {
+			valid = true
+			break
+		}
+	}
+
+	if valid == false {
+		return b.fail(fmt.Errorf("operator %d is not allowed for condition type %d", operator, conditionType))
+	}
+
+	b.conditions = append(b.conditions, ActionFilterConditionObject{
+		ConditionType: conditionType,
+		Operator:      operator,
+		Value:         value,
+		Value2:        value2,
+	})
+
+	return b
+}
+
+func (b *ActionFilterConditionBuilder) fail(err error) *ActionFilterConditionBuilder {
+
+	if b.err == nil {
+		b.err = err
+	}
+
+	return b
+}
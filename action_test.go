@@ -143,3 +143,44 @@ func testActionGet(t *testing.T, z Context, aCreatedIDs []int) []ActionObject {
 
 	return aObjects
 }
+
+func TestActionFilterConditionBuilder(t *testing.T) {
+
+	var b ActionFilterConditionBuilder
+
+	conditions, err := b.HostGroup(ActionFilterConditionOperatorEQ, 2).
+		Trigger(ActionFilterConditionOperatorNE, 13491).
+		Severity(ActionFilterConditionOperatorGE, 3).
+		Tag(ActionFilterConditionOperatorEQ, "service").
+		TagValue(ActionFilterConditionOperatorContains, "service", "mysql").
+		Conditions()
+	if err != nil {
+		t.Fatal("Action filter condition builder error:", err)
+	}
+
+	expected := []ActionFilterConditionObject{
+		{ConditionType: ActionFilterConditionTypeHostroup, Operator: ActionFilterConditionOperatorEQ, Value: "2"},
+		{ConditionType: ActionFilterConditionTypeTrigger, Operator: ActionFilterConditionOperatorNE, Value: "13491"},
+		{ConditionType: ActionFilterConditionTypeTriggerSeverity, Operator: ActionFilterConditionOperatorGE, Value: "3"},
+		{ConditionType: ActionFilterConditionTypeTag, Operator: ActionFilterConditionOperatorEQ, Value: "service"},
+		{ConditionType: ActionFilterConditionTypeTagValue, Operator: ActionFilterConditionOperatorContains, Value: "mysql", Value2: "service"},
+	}
+
+	if reflect.DeepEqual(conditions, expected) == false {
+		t.Fatalf("Action filter condition builder error: unexpected conditions %v", conditions)
+	}
+
+	// Operator is not compatible with condition type
+	var bOp ActionFilterConditionBuilder
+	if _, err := bOp.HostGroup(ActionFilterConditionOperatorGE, 2).Conditions(); err == nil {
+		t.Error("Action filter condition builder error: incompatible operator accepted")
+	}
+
+	// Severity out of range
+	var bSev ActionFilterConditionBuilder
+	if _, err := bSev.Severity(ActionFilterConditionOperatorEQ, 6).Conditions(); err == nil {
+		t.Error("Action filter condition builder error: invalid severity accepted")
+	}
+
+	t.Logf("Action filter condition builder: success")
+}
@@ -0,0 +1,194 @@
+package zabbix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// APIInfoVersion gets Zabbix API version
+func (z *Context) APIInfoVersion() (string, int, error) {
+
+	var result string
+
+	status, err := z.request("apiinfo.version", []string{}, &result)
+	if err != nil {
+		return "", status, err
+	}
+
+	return result, status, nil
+}
+
+// Zabbix API versions server capabilities are introduced in, see `Capabilities()`
+const (
+	capabilitiesRolesVersion    = "5.2"
+	capabilitiesTokensVersion   = "5.4"
+	capabilitiesTimezoneVersion = "6.0"
+)
+
+// ServerCapabilities struct is used to store Zabbix server features available via API
+type ServerCapabilities struct {
+	Version  string // Zabbix API version
+	Roles    bool   // user roles are supported (since Zabbix 5.2)
+	Tokens   bool   // API tokens are supported (since Zabbix 5.4)
+	Timezone string // frontend default timezone, empty before Zabbix 6.0
+}
+
+// Structure to store `settings.get` result fields used by `Capabilities()`
+type capabilitiesSettings struct {
+	DefaultTimezone string `json:"default_timezone"`
+}
+
+// APIVersion returns Zabbix API version. Version is requested from Zabbix once
+// and cached within the context
+func (z *Context) APIVersion() (string, error) {
+
+	if v := z.apiVersionCached(); v != "" {
+		return v, nil
+	}
+
+	// Lock is not held during request, so concurrent first calls may request the version
+	// several times, but the same value is cached
+	v, _, err := z.APIInfoVersion()
+	if err != nil {
+		return "", err
+	}
+
+	contextCachesMu.Lock()
+	z.apiVersion = v
+	contextCachesMu.Unlock()
+
+	return v, nil
+}
+
+// apiVersionCached returns cached Zabbix API version, empty string is returned if version
+// is not requested yet
+func (z *Context) apiVersionCached() string {
+
+	contextCachesMu.Lock()
+	defer contextCachesMu.Unlock()
+
+	return z.apiVersion
+}
+
+// Capabilities returns capabilities of the Zabbix server to gate features centrally. Capabilities are
+// requested from Zabbix once and cached within the context
+func (z *Context) Capabilities() (ServerCapabilities, error) {
+
+	contextCachesMu.Lock()
+	cached := z.capabilities
+	contextCachesMu.Unlock()
+
+	if cached != nil {
+		return *cached, nil
+	}
+
+	v, err := z.APIVersion()
+	if err != nil {
+		return ServerCapabilities{}, err
+	}
+
+	c := ServerCapabilities{
+		Version: v,
+	}
+
+	if c.Roles, err = z.apiVersionAtLeast(capabilitiesRolesVersion); err != nil {
+		return ServerCapabilities{}, err
+	}
+
+	if c.Tokens, err = z.apiVersionAtLeast(capabilitiesTokensVersion); err != nil {
+		return ServerCapabilities{}, err
+	}
+
+	tz, err := z.apiVersionAtLeast(capabilitiesTimezoneVersion)
+	if err != nil {
+		return ServerCapabilities{}, err
+	}
+
+	if tz == true {
+
+		var settings capabilitiesSettings
+
+		if _, err := z.request("settings.get", map[string]interface{}{
+			"output": SelectFields{"default_timezone"},
+		}, &settings); err != nil {
+			return ServerCapabilities{}, fmt.Errorf("get settings error: %v", err)
+		}
+
+		c.Timezone = settings.DefaultTimezone
+	}
+
+	// Lock is not held during requests the same way as for `APIVersion()`
+	contextCachesMu.Lock()
+	z.capabilities = &c
+	contextCachesMu.Unlock()
+
+	return c, nil
+}
+
+// apiVersionAtLeast checks Zabbix API version is equal or greater than `version` (e.g. `5.0`)
+func (z *Context) apiVersionAtLeast(version string) (bool, error) {
+
+	v, err := z.APIVersion()
+	if err != nil {
+		return false, err
+	}
+
+	c, err := versionCompare(v, version)
+	if err != nil {
+		return false, err
+	}
+
+	return c >= 0, nil
+}
+
+// apiVersionRequire returns an error if Zabbix API version is less than `version`
+func (z *Context) apiVersionRequire(version, feature string) error {
+
+	b, err := z.apiVersionAtLeast(version)
+	if err != nil {
+		return err
+	}
+
+	if b == false {
+		return fmt.Errorf("%s requires Zabbix API version %s or later (current version is %s)", feature, version, z.apiVersionCached())
+	}
+
+	return nil
+}
+
+// versionCompare compares two dotted versions (e.g. `5.0.2` and `5.2`) and returns -1, 0 or 1
+// if `a` is less, equal or greater than `b` respectively. Missing parts are treated as zeros
+func versionCompare(a, b string) (int, error) {
+
+	pa := strings.Split(a, ".")
+	pb := strings.Split(b, ".")
+
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+
+		var na, nb int
+		var err error
+
+		if i < len(pa) {
+			if na, err = strconv.Atoi(pa[i]); err != nil {
+				return 0, fmt.Errorf("invalid version `%s`: %v", a, err)
+			}
+		}
+
+		if i < len(pb) {
+			if nb, err = strconv.Atoi(pb[i]); err != nil {
+				return 0, fmt.Errorf("invalid version `%s`: %v", b, err)
+			}
+		}
+
+		if na < nb {
+			return -1, nil
+		}
+
+		if na > nb {
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,174 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestAPIInfoVersion(t *testing.T) {
+
+	var z Context
+
+	// Login
+	loginTest(&z, t)
+	defer logoutTest(&z, t)
+
+	v, _, err := z.APIInfoVersion()
+	if err != nil {
+		t.Fatal("API info version error:", err)
+	}
+
+	if v == "" {
+		t.Fatal("API info version error: empty version")
+	}
+
+	t.Logf("API info version: success")
+}
+
+func TestAPIVersionAtLeast(t *testing.T) {
+
+	var calls int
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+		calls++
+		return "5.0.2", nil
+	})
+	defer closeMock()
+
+	tests := map[string]bool{
+		"4.2":   true,
+		"5.0":   true,
+		"5.0.2": true,
+		"5.0.3": false,
+		"5.2":   false,
+		"6.0":   false,
+	}
+
+	for version, expected := range tests {
+
+		b, err := z.apiVersionAtLeast(version)
+		if err != nil {
+			t.Fatal("API version compare error:", err)
+		}
+
+		if b != expected {
+			t.Errorf("API version compare error: unexpected result for %s", version)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("API version compare error: version is not cached (%d calls)", calls)
+	}
+
+	t.Logf("API version compare: success")
+}
+
+func TestAPIVersionConcurrent(t *testing.T) {
+
+	var wg sync.WaitGroup
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "apiinfo.version" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return "5.0.2", nil
+	})
+	defer closeMock()
+
+	// Version is requested and cached by concurrent first calls
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := z.APIVersion(); err != nil || v != "5.0.2" {
+				t.Errorf("API version concurrent error: unexpected version %s (%v)", v, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	t.Logf("API version concurrent: success")
+}
+
+func TestAPICapabilities(t *testing.T) {
+
+	calls := make(map[string]int)
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		calls[method]++
+
+		switch method {
+		case "apiinfo.version":
+			return "6.0.3", nil
+		case "settings.get":
+			return map[string]interface{}{"default_timezone": "Europe/Riga"}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	for i := 0; i < 2; i++ {
+
+		c, err := z.Capabilities()
+		if err != nil {
+			t.Fatal("API capabilities error:", err)
+		}
+
+		expected := ServerCapabilities{
+			Version:  "6.0.3",
+			Roles:    true,
+			Tokens:   true,
+			Timezone: "Europe/Riga",
+		}
+
+		if c != expected {
+			t.Errorf("API capabilities error: unexpected capabilities %+v", c)
+		}
+	}
+
+	if calls["apiinfo.version"] != 1 || calls["settings.get"] != 1 {
+		t.Errorf("API capabilities error: capabilities are not cached %v", calls)
+	}
+
+	t.Logf("API capabilities: success")
+}
+
+func TestAPICapabilitiesConcurrent(t *testing.T) {
+
+	var wg sync.WaitGroup
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "apiinfo.version":
+			return "6.0.3", nil
+		case "settings.get":
+			return map[string]interface{}{"default_timezone": "Europe/Riga"}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	// Capabilities are requested and cached by concurrent first calls
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if c, err := z.Capabilities(); err != nil || c.Timezone != "Europe/Riga" {
+				t.Errorf("API capabilities concurrent error: unexpected capabilities %+v (%v)", c, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	t.Logf("API capabilities concurrent: success")
+}
@@ -0,0 +1,218 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// For `ConfigurationExportParams` field: `Format`
+const (
+	ConfigurationFormatJSON = "json"
+	ConfigurationFormatXML  = "xml"
+	ConfigurationFormatYAML = "yaml"
+)
+
+// ConfigurationExportParams struct is used for configuration export requests
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/configuration/export#parameters
+type ConfigurationExportParams struct {
+	Format  string                           `json:"format"` // has defined consts, see above
+	Options ConfigurationExportOptionsObject `json:"options"`
+}
+
+// ConfigurationExportOptionsObject struct is used to specify objects to be exported
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/configuration/export#parameters
+type ConfigurationExportOptionsObject struct {
+	Groups     []int `json:"groups,omitempty"`
+	Hosts      []int `json:"hosts,omitempty"`
+	Images     []int `json:"images,omitempty"`
+	Maps       []int `json:"maps,omitempty"`
+	MediaTypes []int `json:"mediaTypes,omitempty"`
+	Screens    []int `json:"screens,omitempty"`
+	Templates  []int `json:"templates,omitempty"`
+	ValueMaps  []int `json:"valueMaps,omitempty"`
+}
+
+// Export struct is used to store parsed configuration export in JSON format (`zabbix_export` element).
+// Only the top level elements are modeled
+type Export struct {
+	Version   string                 `json:"version"`
+	Date      string                 `json:"date"`
+	Groups    []ExportGroupObject    `json:"groups"`
+	Templates []ExportTemplateObject `json:"templates"`
+	ValueMaps []ExportValueMapObject `json:"value_maps"`
+}
+
+// ExportGroupObject struct is used to store exported host group
+type ExportGroupObject struct {
+	Name string `json:"name"`
+}
+
+// ExportTemplateObject struct is used to store exported template.
+// Template entities (e.g. items, triggers) are kept as is and may be parsed by caller
+type ExportTemplateObject struct {
+	Template    string                     `json:"template"`
+	Name        string                     `json:"name"`
+	Description string                     `json:"description"`
+	Groups      []ExportGroupObject        `json:"groups"`
+	Templates   []ExportTemplateLinkObject `json:"templates"`
+	Macros      []ExportMacroObject        `json:"macros"`
+
+	Applications   json.RawMessage `json:"applications,omitempty"`
+	Items          json.RawMessage `json:"items,omitempty"`
+	DiscoveryRules json.RawMessage `json:"discovery_rules,omitempty"`
+	HTTPTests      json.RawMessage `json:"httptests,omitempty"`
+	Screens        json.RawMessage `json:"screens,omitempty"`
+	Tags           json.RawMessage `json:"tags,omitempty"`
+	Triggers       json.RawMessage `json:"triggers,omitempty"`
+}
+
+// ExportTemplateLinkObject struct is used to store linked template of exported template
+type ExportTemplateLinkObject struct {
+	Name string `json:"name"`
+}
+
+// ExportMacroObject struct is used to store exported user macro
+type ExportMacroObject struct {
+	Macro       string `json:"macro"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+}
+
+// ExportValueMapObject struct is used to store exported value map
+type ExportValueMapObject struct {
+	Name     string                        `json:"name"`
+	Mappings []ExportValueMapMappingObject `json:"mappings"`
+}
+
+// ExportValueMapMappingObject struct is used to store exported value map mapping
+type ExportValueMapMappingObject struct {
+	Value    string `json:"value"`
+	NewValue string `json:"newvalue"`
+}
+
+// ConfigurationExport exports configuration data as a serialized string
+func (z *Context) ConfigurationExport(params ConfigurationExportParams) (string, int, error) {
+
+	var result string
+
+	status, err := z.request("configuration.export", params, &result)
+	if err != nil {
+		return "", status, err
+	}
+
+	return result, status, nil
+}
+
+// ExportTemplatesStruct exports templates in JSON format and parses the result into `Export` struct
+func (z *Context) ExportTemplatesStruct(templateIDs []int) (*Export, error) {
+
+	var e struct {
+		ZabbixExport Export `json:"zabbix_export"`
+	}
+
+	s, _, err := z.ConfigurationExport(ConfigurationExportParams{
+		Format: ConfigurationFormatJSON,
+		Options: ConfigurationExportOptionsObject{
+			Templates: templateIDs,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(s), &e); err != nil {
+		return nil, fmt.Errorf("parse export error: %v", err)
+	}
+
+	return &e.ZabbixExport, nil
+}
+
+// ExportGroupBundle exports the host group with all its hosts and templates linked to them (including nested
+// templates) within a single `configuration.export` call, so the result can be imported as a whole.
+// Templates shared by several hosts are exported once. `format` is one of `ConfigurationFormat*` consts
+func (z *Context) ExportGroupBundle(groupID int, format string) (string, error) {
+
+	switch format {
+	case ConfigurationFormatJSON, ConfigurationFormatXML, ConfigurationFormatYAML:
+	default:
+		return "", fmt.Errorf("unknown export format `%s`", format)
+	}
+
+	hObjects, _, err := z.HostGet(HostGetParams{
+		GroupIDs:              []int{groupID},
+		SelectParentTemplates: SelectFields{"templateid"},
+		GetParameters: GetParameters{
+			Output: SelectFields{"hostid"},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("get hosts error: %v", err)
+	}
+
+	var hostIDs, queue []int
+
+	templates := make(map[int]bool)
+
+	for _, h := range hObjects {
+
+		hostIDs = append(hostIDs, h.HostID)
+
+		for _, t := range h.ParentTemplates {
+			if templates[t.TemplateID] == false {
+				templates[t.TemplateID] = true
+				queue = append(queue, t.TemplateID)
+			}
+		}
+	}
+
+	// Nested templates
+	for len(queue) > 0 {
+
+		tObjects, _, err := z.TemplateGet(TemplateGetParams{
+			TemplateIDs:           queue,
+			SelectParentTemplates: SelectFields{"templateid"},
+			GetParameters: GetParameters{
+				Output: SelectFields{"templateid"},
+			},
+		})
+		if err != nil {
+			return "", fmt.Errorf("get templates error: %v", err)
+		}
+
+		queue = nil
+
+		for _, t := range tObjects {
+			for _, p := range t.ParentTemplates {
+				if templates[p.TemplateID] == false {
+					templates[p.TemplateID] = true
+					queue = append(queue, p.TemplateID)
+				}
+			}
+		}
+	}
+
+	var templateIDs []int
+	for id := range templates {
+		templateIDs = append(templateIDs, id)
+	}
+
+	sort.Ints(hostIDs)
+	sort.Ints(templateIDs)
+
+	s, _, err := z.ConfigurationExport(ConfigurationExportParams{
+		Format: format,
+		Options: ConfigurationExportOptionsObject{
+			Groups:    []int{groupID},
+			Hosts:     hostIDs,
+			Templates: templateIDs,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return s, nil
+}
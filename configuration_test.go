@@ -0,0 +1,166 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigurationExport(t *testing.T) {
+
+	var z Context
+
+	// Login
+	loginTest(&z, t)
+	defer logoutTest(&z, t)
+
+	// Preparing auxiliary data
+	hgCreatedIDs := testHostgroupCreate(t, z)
+	defer testHostgroupDelete(t, z, hgCreatedIDs)
+
+	tCreatedIDs := testTemplateCreate(t, z, hgCreatedIDs)
+	defer testTemplateDelete(t, z, tCreatedIDs)
+
+	// Export
+	testConfigurationExportTemplates(t, z, tCreatedIDs)
+}
+
+func TestConfigurationExportTemplatesStruct(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p ConfigurationExportParams
+
+		if method != "configuration.export" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		if p.Format != ConfigurationFormatJSON || len(p.Options.Templates) != 1 || p.Options.Templates[0] != 10001 {
+			return nil, fmt.Errorf("unexpected params %s", string(params))
+		}
+
+		return `{"zabbix_export":{"version":"5.0","date":"2020-06-01T10:00:00Z",` +
+			`"groups":[{"name":"Templates"}],` +
+			`"templates":[{"template":"testTemplate","name":"testTemplate","groups":[{"name":"Templates"}],"items":[{"name":"Ping","key":"agent.ping"}]}],` +
+			`"value_maps":[{"name":"Service state","mappings":[{"value":"0","newvalue":"Down"},{"value":"1","newvalue":"Up"}]}]}}`, nil
+	})
+	defer closeMock()
+
+	e, err := z.ExportTemplatesStruct([]int{10001})
+	if err != nil {
+		t.Fatal("Configuration export templates struct error:", err)
+	}
+
+	if e.Version != "5.0" ||
+		len(e.Groups) != 1 ||
+		len(e.Templates) != 1 || e.Templates[0].Name != testTemplateName ||
+		len(e.ValueMaps) != 1 || len(e.ValueMaps[0].Mappings) != 2 {
+		t.Errorf("Configuration export templates struct error: unexpected result %+v", e)
+	}
+
+	t.Logf("Configuration export templates struct: success")
+}
+
+func testConfigurationExportTemplates(t *testing.T, z Context, tCreatedIDs []int) *Export {
+
+	e, err := z.ExportTemplatesStruct(tCreatedIDs)
+	if err != nil {
+		t.Fatal("Configuration export error:", err)
+	}
+
+	if len(e.Templates) != 1 || e.Templates[0].Name != testTemplateName {
+		t.Fatal("Configuration export error: unable to find exported template")
+	}
+
+	t.Logf("Configuration export: success")
+
+	return e
+}
+
+func TestConfigurationExportGroupBundle(t *testing.T) {
+
+	templates := map[int][]int{
+		10001: {10003},
+		10002: {},
+		10003: {},
+	}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "host.get":
+
+			return []map[string]interface{}{
+				{"hostid": "10084", "parentTemplates": []map[string]interface{}{{"templateid": "10001"}, {"templateid": "10002"}}},
+				{"hostid": "10085", "parentTemplates": []map[string]interface{}{{"templateid": "10001"}}},
+			}, nil
+
+		case "template.get":
+
+			var p TemplateGetParams
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			r := []map[string]interface{}{}
+			for _, id := range p.TemplateIDs {
+
+				parents := []map[string]interface{}{}
+				for _, l := range templates[id] {
+					parents = append(parents, map[string]interface{}{"templateid": l})
+				}
+
+				r = append(r, map[string]interface{}{"templateid": id, "parentTemplates": parents})
+			}
+
+			return r, nil
+
+		case "configuration.export":
+
+			var p ConfigurationExportParams
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			expected := ConfigurationExportOptionsObject{
+				Groups:    []int{5},
+				Hosts:     []int{10084, 10085},
+				Templates: []int{10001, 10002, 10003},
+			}
+
+			if p.Format != ConfigurationFormatYAML || reflect.DeepEqual(p.Options, expected) == false {
+				return nil, fmt.Errorf("unexpected params %s", string(params))
+			}
+
+			return "zabbix_export:\n  hosts:\n    - host: web-1\n    - host: web-2\n  templates:\n    - template: Linux\n", nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	s, err := z.ExportGroupBundle(5, ConfigurationFormatYAML)
+	if err != nil {
+		t.Fatal("Configuration export group bundle error:", err)
+	}
+
+	for _, e := range []string{"host: web-1", "host: web-2", "template: Linux"} {
+		if strings.Contains(s, e) == false {
+			t.Errorf("Configuration export group bundle error: `%s` not found in export %s", e, s)
+		}
+	}
+
+	if _, err := z.ExportGroupBundle(5, "csv"); err == nil {
+		t.Error("Configuration export group bundle error: expected error for unknown format")
+	}
+
+	t.Logf("Configuration export group bundle: success")
+}
@@ -0,0 +1,257 @@
+package zabbix
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// For `DashboardObject` field: `Private`
+const (
+	DashboardPrivatePublic  = 0
+	DashboardPrivatePrivate = 1
+)
+
+// For `DashboardWidgetFieldObject` field: `Type`
+const (
+	DashboardWidgetFieldTypeInteger        = 0
+	DashboardWidgetFieldTypeString         = 1
+	DashboardWidgetFieldTypeHostGroup      = 2
+	DashboardWidgetFieldTypeHost           = 3
+	DashboardWidgetFieldTypeItem           = 4
+	DashboardWidgetFieldTypeItemPrototype  = 5
+	DashboardWidgetFieldTypeGraph          = 6
+	DashboardWidgetFieldTypeGraphPrototype = 7
+	DashboardWidgetFieldTypeMap            = 8
+)
+
+// Entity types of widget fields referencing objects, used as `RemapWidgetFields()` ID map keys
+var dashboardWidgetFieldEntities = map[int]string{
+	DashboardWidgetFieldTypeHostGroup:      "group",
+	DashboardWidgetFieldTypeHost:           "host",
+	DashboardWidgetFieldTypeItem:           "item",
+	DashboardWidgetFieldTypeItemPrototype:  "item_prototype",
+	DashboardWidgetFieldTypeGraph:          "graph",
+	DashboardWidgetFieldTypeGraphPrototype: "graph_prototype",
+	DashboardWidgetFieldTypeMap:            "map",
+}
+
+// Zabbix API version dashboard pages are introduced in
+const dashboardPagesVersion = "5.4"
+
+// DashboardObject struct is used to store dashboard operations results
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/dashboard/object#dashboard
+type DashboardObject struct {
+	DashboardID int    `json:"dashboardid,omitempty"`
+	Name        string `json:"name,omitempty"`
+	UserID      int    `json:"userid,omitempty"`
+	Private     int    `json:"private"` // has defined consts, see above
+
+	Widgets []DashboardWidgetObject `json:"widgets,omitempty"` // used before Zabbix 5.4
+	Pages   []DashboardPageObject   `json:"pages,omitempty"`   // used since Zabbix 5.4
+}
+
+// DashboardPageObject struct is used to store dashboard page (since Zabbix 5.4)
+//
+// see: https://www.zabbix.com/documentation/5.4/manual/api/reference/dashboard/object#dashboard_page
+type DashboardPageObject struct {
+	DashboardPageID int    `json:"dashboard_pageid,omitempty"`
+	Name            string `json:"name,omitempty"`
+	DisplayPeriod   int    `json:"display_period,omitempty"`
+
+	Widgets []DashboardWidgetObject `json:"widgets,omitempty"`
+}
+
+// DashboardWidgetObject struct is used to store dashboard widget
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/dashboard/object#dashboard_widget
+type DashboardWidgetObject struct {
+	WidgetID int    `json:"widgetid,omitempty"`
+	Type     string `json:"type"`
+	Name     string `json:"name,omitempty"`
+	X        int    `json:"x"`
+	Y        int    `json:"y"`
+	Width    int    `json:"width,omitempty"`
+	Height   int    `json:"height,omitempty"`
+	ViewMode int    `json:"view_mode,omitempty"`
+
+	Fields []DashboardWidgetFieldObject `json:"fields,omitempty"`
+}
+
+// DashboardWidgetFieldObject struct is used to store dashboard widget field.
+// For fields referencing objects (e.g. `DashboardWidgetFieldTypeItem`) `Value` is the object ID
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/dashboard/object#dashboard_widget_field
+type DashboardWidgetFieldObject struct {
+	Type  int    `json:"type"` // has defined consts, see above
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// DashboardGetParams struct is used for dashboard get requests
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/dashboard/get#parameters
+type DashboardGetParams struct {
+	GetParameters
+
+	DashboardIDs []int `json:"dashboardids,omitempty"`
+
+	SelectWidgets SelectQuery `json:"selectWidgets,omitempty"` // used before Zabbix 5.4
+	SelectPages   SelectQuery `json:"selectPages,omitempty"`   // used since Zabbix 5.4
+	// SelectUsers      SelectQuery `json:"selectUsers,omitempty"` // not implemented yet
+	// SelectUserGroups SelectQuery `json:"selectUserGroups,omitempty"` // not implemented yet
+}
+
+// Structure to store creation result
+type dashboardCreateResult struct {
+	DashboardIDs []int `json:"dashboardids"`
+}
+
+// Structure to store deletion result
+type dashboardDeleteResult struct {
+	DashboardIDs []int `json:"dashboardids"`
+}
+
+// DashboardGet gets dashboards
+func (z *Context) DashboardGet(params DashboardGetParams) ([]DashboardObject, int, error) {
+
+	var result []DashboardObject
+
+	status, err := z.request("dashboard.get", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result, status, nil
+}
+
+// DashboardCreate creates dashboards
+func (z *Context) DashboardCreate(params []DashboardObject) ([]int, int, error) {
+
+	var result dashboardCreateResult
+
+	status, err := z.request("dashboard.create", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.DashboardIDs, status, nil
+}
+
+// DashboardDelete deletes dashboards
+func (z *Context) DashboardDelete(dashboardIDs []int) ([]int, int, error) {
+
+	var result dashboardDeleteResult
+
+	status, err := z.request("dashboard.delete", dashboardIDs, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.DashboardIDs, status, nil
+}
+
+// CloneDashboard creates a copy of the dashboard owned by `newOwnerUserID` with `newName` and returns
+// ID of the created dashboard. Pages (since Zabbix 5.4) and widgets are copied as is, including widget
+// fields referencing other objects. Dashboard sharing settings are not copied, so the copy is private
+func (z *Context) CloneDashboard(dashboardID, newOwnerUserID int, newName string) (int, error) {
+
+	pages, err := z.apiVersionAtLeast(dashboardPagesVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	params := DashboardGetParams{
+		DashboardIDs: []int{dashboardID},
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	}
+
+	if pages == true {
+		params.SelectPages = SelectExtendedOutput
+	} else {
+		params.SelectWidgets = SelectExtendedOutput
+	}
+
+	dObjects, _, err := z.DashboardGet(params)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(dObjects) == 0 {
+		return 0, fmt.Errorf("dashboard with id %d not found", dashboardID)
+	}
+
+	d := dObjects[0]
+
+	c := DashboardObject{
+		Name:    newName,
+		UserID:  newOwnerUserID,
+		Private: DashboardPrivatePrivate,
+		Widgets: dashboardWidgetsCopy(d.Widgets),
+	}
+
+	for _, p := range d.Pages {
+		c.Pages = append(c.Pages, DashboardPageObject{
+			Name:          p.Name,
+			DisplayPeriod: p.DisplayPeriod,
+			Widgets:       dashboardWidgetsCopy(p.Widgets),
+		})
+	}
+
+	dCreatedIDs, _, err := z.DashboardCreate([]DashboardObject{c})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(dCreatedIDs) == 0 {
+		return 0, fmt.Errorf("dashboard create error: empty IDs array")
+	}
+
+	return dCreatedIDs[0], nil
+}
+
+// dashboardWidgetsCopy returns copy of widgets with cleared IDs
+func dashboardWidgetsCopy(widgets []DashboardWidgetObject) []DashboardWidgetObject {
+
+	var r []DashboardWidgetObject
+
+	for _, w := range widgets {
+		w.WidgetID = 0
+		w.Fields = append([]DashboardWidgetFieldObject(nil), w.Fields...)
+		r = append(r, w)
+	}
+
+	return r
+}
+
+// RemapWidgetFields returns copy of widgets with object IDs in widget fields replaced according to `idMap`,
+// e.g. to migrate dashboards between servers. `idMap` is keyed by entity type (`group`, `host`, `item`,
+// `item_prototype`, `graph`, `graph_prototype` or `map`) and contains old to new IDs maps. Widget IDs are
+// cleared, fields with IDs not found in `idMap` are kept as is
+func RemapWidgetFields(widgets []DashboardWidgetObject, idMap map[string]map[int]int) []DashboardWidgetObject {
+
+	r := dashboardWidgetsCopy(widgets)
+
+	for _, w := range r {
+		for i, f := range w.Fields {
+
+			e, b := dashboardWidgetFieldEntities[f.Type]
+			if b == false {
+				continue
+			}
+
+			id, err := strconv.Atoi(f.Value)
+			if err != nil {
+				continue
+			}
+
+			if n, b := idMap[e][id]; b == true {
+				w.Fields[i].Value = strconv.Itoa(n)
+			}
+		}
+	}
+
+	return r
+}
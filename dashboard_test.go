@@ -0,0 +1,156 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDashboardClone(t *testing.T) {
+
+	widget := map[string]interface{}{
+		"widgetid": "31",
+		"type":     "graph",
+		"name":     "CPU",
+		"x":        "0",
+		"y":        "0",
+		"width":    "12",
+		"height":   "5",
+		"fields": []map[string]interface{}{
+			{"type": "6", "name": "graphid", "value": "5501"},
+		},
+	}
+
+	tests := []struct {
+		version   string
+		dashboard map[string]interface{}
+		expected  string
+	}{
+		{
+			version: "5.0.2",
+			dashboard: map[string]interface{}{
+				"dashboardid": "7",
+				"name":        "My dashboard",
+				"userid":      "1",
+				"private":     "1",
+				"widgets":     []interface{}{widget},
+			},
+			expected: `[{"name":"Shared copy","private":1,"userid":5,"widgets":[{"fields":[{"name":"graphid","type":6,"value":"5501"}],"height":5,"name":"CPU","type":"graph","width":12,"x":0,"y":0}]}]`,
+		},
+		{
+			version: "6.0.0",
+			dashboard: map[string]interface{}{
+				"dashboardid": "7",
+				"name":        "My dashboard",
+				"userid":      "1",
+				"private":     "1",
+				"pages": []map[string]interface{}{
+					{"dashboard_pageid": "9", "name": "", "display_period": "0", "widgets": []interface{}{widget}},
+				},
+			},
+			expected: `[{"name":"Shared copy","pages":[{"widgets":[{"fields":[{"name":"graphid","type":6,"value":"5501"}],"height":5,"name":"CPU","type":"graph","width":12,"x":0,"y":0}]}],"private":1,"userid":5}]`,
+		},
+	}
+
+	for _, tt := range tests {
+
+		var created []map[string]interface{}
+
+		z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+			switch method {
+			case "apiinfo.version":
+				return tt.version, nil
+			case "dashboard.get":
+				return []map[string]interface{}{tt.dashboard}, nil
+			case "dashboard.create":
+
+				if err := json.Unmarshal(params, &created); err != nil {
+					return nil, err
+				}
+
+				return map[string]interface{}{"dashboardids": []string{"8"}}, nil
+			}
+
+			return nil, fmt.Errorf("unexpected method %s", method)
+		})
+
+		id, err := z.CloneDashboard(7, 5, "Shared copy")
+		closeMock()
+
+		if err != nil {
+			t.Fatalf("Dashboard clone error (%s): %v", tt.version, err)
+		}
+
+		if id != 8 {
+			t.Errorf("Dashboard clone error (%s): unexpected ID %d", tt.version, id)
+		}
+
+		b, _ := json.Marshal(created)
+		if string(b) != tt.expected {
+			t.Errorf("Dashboard clone error (%s): unexpected params %s", tt.version, string(b))
+		}
+	}
+
+	t.Logf("Dashboard clone: success")
+}
+
+func TestDashboardRemapWidgetFields(t *testing.T) {
+
+	widgets := []DashboardWidgetObject{
+		{
+			WidgetID: 31,
+			Type:     "problemsbysv",
+			Name:     "Problems by severity",
+			Fields: []DashboardWidgetFieldObject{
+				{Type: DashboardWidgetFieldTypeHostGroup, Name: "groupids", Value: "2"},
+				{Type: DashboardWidgetFieldTypeHostGroup, Name: "groupids", Value: "9"},
+				{Type: DashboardWidgetFieldTypeInteger, Name: "show_type", Value: "2"},
+			},
+		},
+		{
+			WidgetID: 32,
+			Type:     "plaintext",
+			Fields: []DashboardWidgetFieldObject{
+				{Type: DashboardWidgetFieldTypeItem, Name: "itemids", Value: "23661"},
+			},
+		},
+	}
+
+	idMap := map[string]map[int]int{
+		"group": {2: 15},
+		"item":  {23661: 41002},
+	}
+
+	r := RemapWidgetFields(widgets, idMap)
+
+	expected := []DashboardWidgetObject{
+		{
+			Type: "problemsbysv",
+			Name: "Problems by severity",
+			Fields: []DashboardWidgetFieldObject{
+				{Type: DashboardWidgetFieldTypeHostGroup, Name: "groupids", Value: "15"},
+				{Type: DashboardWidgetFieldTypeHostGroup, Name: "groupids", Value: "9"},
+				{Type: DashboardWidgetFieldTypeInteger, Name: "show_type", Value: "2"},
+			},
+		},
+		{
+			Type: "plaintext",
+			Fields: []DashboardWidgetFieldObject{
+				{Type: DashboardWidgetFieldTypeItem, Name: "itemids", Value: "41002"},
+			},
+		},
+	}
+
+	if reflect.DeepEqual(r, expected) == false {
+		t.Errorf("Dashboard remap widget fields error: unexpected widgets %v", r)
+	}
+
+	// Source widgets are not changed
+	if widgets[0].Fields[0].Value != "2" || widgets[0].WidgetID != 31 {
+		t.Errorf("Dashboard remap widget fields error: source widgets changed %v", widgets)
+	}
+
+	t.Logf("Dashboard remap widget fields: success")
+}
@@ -0,0 +1,330 @@
+package zabbix
+
+// For `DiscoveryruleObject` field: `Status`
+const (
+	DiscoveryruleStatusEnabled  = 0
+	DiscoveryruleStatusDisabled = 1
+)
+
+// For `DiscoveryruleObject` field: `State`
+const (
+	DiscoveryruleStateNormal       = 0
+	DiscoveryruleStateNotSupported = 1
+)
+
+// For `DiscoveryruleFilterObject` field: `EvalType`
+const (
+	DiscoveryruleFilterEvalTypeAndOr  = 0
+	DiscoveryruleFilterEvalTypeAnd    = 1
+	DiscoveryruleFilterEvalTypeOr     = 2
+	DiscoveryruleFilterEvalTypeCustom = 3
+)
+
+// For `DiscoveryruleFilterConditionObject` field: `Operator`
+const (
+	DiscoveryruleFilterConditionOperatorMatches    = 8
+	DiscoveryruleFilterConditionOperatorNotMatches = 9
+)
+
+// For `DiscoveryruleOverrideObject` field: `Stop`
+const (
+	DiscoveryruleOverrideStopNo  = 0
+	DiscoveryruleOverrideStopYes = 1
+)
+
+// For `DiscoveryruleOverrideOperationObject` field: `OperationObject`
+const (
+	DiscoveryruleOverrideOperationObjectItemPrototype    = 0
+	DiscoveryruleOverrideOperationObjectTriggerPrototype = 1
+	DiscoveryruleOverrideOperationObjectGraphPrototype   = 2
+	DiscoveryruleOverrideOperationObjectHostPrototype    = 3
+)
+
+// For `DiscoveryruleOverrideOperationObject` field: `Operator`
+const (
+	DiscoveryruleOverrideOperationOperatorEquals     = 0
+	DiscoveryruleOverrideOperationOperatorNotEquals  = 1
+	DiscoveryruleOverrideOperationOperatorContains   = 2
+	DiscoveryruleOverrideOperationOperatorNotContain = 3
+	DiscoveryruleOverrideOperationOperatorMatches    = 8
+	DiscoveryruleOverrideOperationOperatorNotMatches = 9
+)
+
+// Minimal Zabbix API versions required for some discovery rule features
+const (
+	discoveryruleLLDMacroPathsVersion = "4.2"
+	discoveryruleOverridesVersion     = "5.0"
+)
+
+// DiscoveryruleObject struct is used to store LLD rule operations results
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/discoveryrule/object#lld_rule
+type DiscoveryruleObject struct {
+	ItemID      int    `json:"itemid,omitempty"`
+	Delay       string `json:"delay,omitempty"`
+	HostID      int    `json:"hostid,omitempty"`
+	InterfaceID int    `json:"interfaceid,omitempty"`
+	Key         string `json:"key_,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Type        int    `json:"type"`
+	Description string `json:"description,omitempty"`
+	Error       string `json:"error,omitempty"`
+	Lifetime    string `json:"lifetime,omitempty"`
+	State       int    `json:"state,omitempty"`  // has defined consts, see above
+	Status      int    `json:"status,omitempty"` // has defined consts, see above
+	TemplateID  int    `json:"templateid,omitempty"`
+
+	Filter        *DiscoveryruleFilterObject         `json:"filter,omitempty"`
+	LLDMacroPaths []DiscoveryruleLLDMacroPathObject  `json:"lld_macro_paths,omitempty"` // Zabbix API 4.2 or later
+	Preprocessing []DiscoveryrulePreprocessingObject `json:"preprocessing,omitempty"`
+	Overrides     []DiscoveryruleOverrideObject      `json:"overrides,omitempty"` // Zabbix API 5.0 or later
+	Hosts         []HostObject                       `json:"hosts,omitempty"`
+}
+
+// DiscoveryruleFilterObject struct is used to store LLD rule filter
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/discoveryrule/object#lld_rule_filter
+type DiscoveryruleFilterObject struct {
+	Conditions  []DiscoveryruleFilterConditionObject `json:"conditions"`
+	EvalType    int                                  `json:"evaltype"` // has defined consts, see above
+	EvalFormula string                               `json:"eval_formula,omitempty"`
+	Formula     string                               `json:"formula,omitempty"`
+}
+
+// DiscoveryruleFilterConditionObject struct is used to store LLD rule filter condition
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/discoveryrule/object#lld_rule_filter_condition
+type DiscoveryruleFilterConditionObject struct {
+	Macro     string `json:"macro"`
+	Value     string `json:"value"`
+	FormulaID string `json:"formulaid,omitempty"`
+	Operator  int    `json:"operator,omitempty"` // has defined consts, see above
+}
+
+// DiscoveryruleLLDMacroPathObject struct is used to store LLD rule macro path
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/discoveryrule/object#lld_macro_path
+type DiscoveryruleLLDMacroPathObject struct {
+	LLDMacro string `json:"lld_macro"`
+	Path     string `json:"path"`
+}
+
+// DiscoveryrulePreprocessingObject struct is used to store LLD rule preprocessing step
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/discoveryrule/object#lld_rule_preprocessing
+type DiscoveryrulePreprocessingObject struct {
+	Type               int    `json:"type"`
+	Params             string `json:"params"`
+	ErrorHandler       int    `json:"error_handler"`
+	ErrorHandlerParams string `json:"error_handler_params"`
+}
+
+// DiscoveryruleOverrideObject struct is used to store LLD rule override
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/discoveryrule/object#lld_rule_overrides
+type DiscoveryruleOverrideObject struct {
+	Name       string                                 `json:"name"`
+	Step       int                                    `json:"step"`
+	Stop       int                                    `json:"stop,omitempty"` // has defined consts, see above
+	Filter     *DiscoveryruleFilterObject             `json:"filter,omitempty"`
+	Operations []DiscoveryruleOverrideOperationObject `json:"operations,omitempty"`
+}
+
+// DiscoveryruleOverrideOperationObject struct is used to store LLD rule override operation
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/discoveryrule/object#override_operation
+type DiscoveryruleOverrideOperationObject struct {
+	OperationObject int    `json:"operationobject"`    // has defined consts, see above
+	Operator        int    `json:"operator,omitempty"` // has defined consts, see above
+	Value           string `json:"value,omitempty"`
+
+	Opstatus    *DiscoveryruleOverrideOpstatusObject    `json:"opstatus,omitempty"`
+	Opdiscover  *DiscoveryruleOverrideOpdiscoverObject  `json:"opdiscover,omitempty"`
+	Opperiod    *DiscoveryruleOverrideOpperiodObject    `json:"opperiod,omitempty"`
+	Ophistory   *DiscoveryruleOverrideOphistoryObject   `json:"ophistory,omitempty"`
+	Optrends    *DiscoveryruleOverrideOptrendsObject    `json:"optrends,omitempty"`
+	Opseverity  *DiscoveryruleOverrideOpseverityObject  `json:"opseverity,omitempty"`
+	Optag       []DiscoveryruleOverrideOptagObject      `json:"optag,omitempty"`
+	Optemplate  []DiscoveryruleOverrideOptemplateObject `json:"optemplate,omitempty"`
+	Opinventory *DiscoveryruleOverrideOpinventoryObject `json:"opinventory,omitempty"`
+}
+
+// DiscoveryruleOverrideOpstatusObject struct is used to store override operation status
+type DiscoveryruleOverrideOpstatusObject struct {
+	Status int `json:"status"`
+}
+
+// DiscoveryruleOverrideOpdiscoverObject struct is used to store override operation discover
+type DiscoveryruleOverrideOpdiscoverObject struct {
+	Discover int `json:"discover"`
+}
+
+// DiscoveryruleOverrideOpperiodObject struct is used to store override operation period
+type DiscoveryruleOverrideOpperiodObject struct {
+	Delay string `json:"delay"`
+}
+
+// DiscoveryruleOverrideOphistoryObject struct is used to store override operation history
+type DiscoveryruleOverrideOphistoryObject struct {
+	History string `json:"history"`
+}
+
+// DiscoveryruleOverrideOptrendsObject struct is used to store override operation trends
+type DiscoveryruleOverrideOptrendsObject struct {
+	Trends string `json:"trends"`
+}
+
+// DiscoveryruleOverrideOpseverityObject struct is used to store override operation severity
+type DiscoveryruleOverrideOpseverityObject struct {
+	Severity int `json:"severity"`
+}
+
+// DiscoveryruleOverrideOptagObject struct is used to store override operation tag
+type DiscoveryruleOverrideOptagObject struct {
+	Tag   string `json:"tag"`
+	Value string `json:"value,omitempty"`
+}
+
+// DiscoveryruleOverrideOptemplateObject struct is used to store override operation template
+type DiscoveryruleOverrideOptemplateObject struct {
+	TemplateID int `json:"templateid"`
+}
+
+// DiscoveryruleOverrideOpinventoryObject struct is used to store override operation inventory
+type DiscoveryruleOverrideOpinventoryObject struct {
+	InventoryMode int `json:"inventory_mode"`
+}
+
+// DiscoveryruleGetParams struct is used for LLD rule get requests
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/discoveryrule/get#parameters
+type DiscoveryruleGetParams struct {
+	GetParameters
+
+	ItemIDs      []int `json:"itemids,omitempty"`
+	GroupIDs     []int `json:"groupids,omitempty"`
+	HostIDs      []int `json:"hostids,omitempty"`
+	Inherited    bool  `json:"inherited,omitempty"`
+	InterfaceIDs []int `json:"interfaceids,omitempty"`
+	Monitored    bool  `json:"monitored,omitempty"`
+	Templated    bool  `json:"templated,omitempty"`
+	TemplateIDs  []int `json:"templateids,omitempty"`
+
+	SelectFilter SelectQuery `json:"selectFilter,omitempty"`
+	// SelectGraphs         SelectQuery `json:"selectGraphs,omitempty"` // not implemented yet
+	// SelectHostPrototypes SelectQuery `json:"selectHostPrototypes,omitempty"` // not implemented yet
+	SelectHosts SelectQuery `json:"selectHosts,omitempty"`
+	// SelectItems          SelectQuery `json:"selectItems,omitempty"` // not implemented yet
+	// SelectTriggers       SelectQuery `json:"selectTriggers,omitempty"` // not implemented yet
+	SelectLLDMacroPaths SelectQuery `json:"selectLLDMacroPaths,omitempty"` // Zabbix API 4.2 or later
+	SelectPreprocessing SelectQuery `json:"selectPreprocessing,omitempty"`
+	SelectOverrides     SelectQuery `json:"selectOverrides,omitempty"` // Zabbix API 5.0 or later
+}
+
+// Structure to store creation result
+type discoveryruleCreateResult struct {
+	ItemIDs []int `json:"itemids"`
+}
+
+// Structure to store updation result
+type discoveryruleUpdateResult struct {
+	ItemIDs []int `json:"itemids"`
+}
+
+// Structure to store deletion result
+type discoveryruleDeleteResult struct {
+	RuleIDs []int `json:"ruleids"`
+}
+
+// DiscoveryruleGet gets LLD rules
+func (z *Context) DiscoveryruleGet(params DiscoveryruleGetParams) ([]DiscoveryruleObject, int, error) {
+
+	var result []DiscoveryruleObject
+
+	if params.SelectLLDMacroPaths != nil {
+		if err := z.apiVersionRequire(discoveryruleLLDMacroPathsVersion, "LLD rule macro paths"); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if params.SelectOverrides != nil {
+		if err := z.apiVersionRequire(discoveryruleOverridesVersion, "LLD rule overrides"); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	status, err := z.request("discoveryrule.get", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result, status, nil
+}
+
+// DiscoveryruleCreate creates LLD rules
+func (z *Context) DiscoveryruleCreate(params []DiscoveryruleObject) ([]int, int, error) {
+
+	var result discoveryruleCreateResult
+
+	if err := z.discoveryruleVersionCheck(params); err != nil {
+		return nil, 0, err
+	}
+
+	status, err := z.request("discoveryrule.create", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.ItemIDs, status, nil
+}
+
+// DiscoveryruleUpdate updates LLD rules
+func (z *Context) DiscoveryruleUpdate(params []DiscoveryruleObject) ([]int, int, error) {
+
+	var result discoveryruleUpdateResult
+
+	if err := z.discoveryruleVersionCheck(params); err != nil {
+		return nil, 0, err
+	}
+
+	status, err := z.request("discoveryrule.update", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.ItemIDs, status, nil
+}
+
+// DiscoveryruleDelete deletes LLD rules
+func (z *Context) DiscoveryruleDelete(ruleIDs []int) ([]int, int, error) {
+
+	var result discoveryruleDeleteResult
+
+	status, err := z.request("discoveryrule.delete", ruleIDs, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.RuleIDs, status, nil
+}
+
+// discoveryruleVersionCheck checks Zabbix API version supports features used in LLD rules
+func (z *Context) discoveryruleVersionCheck(params []DiscoveryruleObject) error {
+
+	for _, p := range params {
+
+		if len(p.LLDMacroPaths) > 0 {
+			if err := z.apiVersionRequire(discoveryruleLLDMacroPathsVersion, "LLD rule macro paths"); err != nil {
+				return err
+			}
+		}
+
+		if len(p.Overrides) > 0 {
+			if err := z.apiVersionRequire(discoveryruleOverridesVersion, "LLD rule overrides"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
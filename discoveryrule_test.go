@@ -0,0 +1,152 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const (
+	testDiscoveryruleName        = "testDiscoveryrule"
+	testDiscoveryruleKey         = "test.discovery"
+	testDiscoveryruleTypeTrapper = 2
+)
+
+func TestDiscoveryruleCRUD(t *testing.T) {
+
+	var z Context
+
+	// Login
+	loginTest(&z, t)
+	defer logoutTest(&z, t)
+
+	// Preparing auxiliary data
+	hgCreatedIDs := testHostgroupCreate(t, z)
+	defer testHostgroupDelete(t, z, hgCreatedIDs)
+
+	tCreatedIDs := testTemplateCreate(t, z, hgCreatedIDs)
+	defer testTemplateDelete(t, z, tCreatedIDs)
+
+	hCreatedIDs := testHostCreate(t, z, hgCreatedIDs, tCreatedIDs)
+	defer testHostDelete(t, z, hCreatedIDs)
+
+	// Create and delete
+	drCreatedIDs := testDiscoveryruleCreate(t, z, hCreatedIDs[0])
+	defer testDiscoveryruleDelete(t, z, drCreatedIDs)
+
+	// Get
+	testDiscoveryruleGet(t, z, drCreatedIDs)
+}
+
+func TestDiscoveryruleVersionCheck(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+		return "4.0.0", nil
+	})
+	defer closeMock()
+
+	_, _, err := z.DiscoveryruleCreate([]DiscoveryruleObject{
+		{
+			Name: testDiscoveryruleName,
+			Overrides: []DiscoveryruleOverrideObject{
+				{
+					Name: "testOverride",
+					Step: 1,
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatal("Discoveryrule version check error: overrides accepted for Zabbix API 4.0")
+	}
+
+	t.Logf("Discoveryrule version check: success")
+}
+
+func testDiscoveryruleCreate(t *testing.T, z Context, hCreatedID int) []int {
+
+	drCreatedIDs, _, err := z.DiscoveryruleCreate([]DiscoveryruleObject{
+		{
+			HostID: hCreatedID,
+			Name:   testDiscoveryruleName,
+			Key:    testDiscoveryruleKey,
+			Type:   testDiscoveryruleTypeTrapper,
+			Filter: &DiscoveryruleFilterObject{
+				EvalType: DiscoveryruleFilterEvalTypeAnd,
+				Conditions: []DiscoveryruleFilterConditionObject{
+					{
+						Macro:    "{#FSNAME}",
+						Value:    "^/data",
+						Operator: DiscoveryruleFilterConditionOperatorMatches,
+					},
+					{
+						Macro:    "{#FSTYPE}",
+						Value:    "^tmpfs$",
+						Operator: DiscoveryruleFilterConditionOperatorNotMatches,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal("Discoveryrule create error:", err)
+	}
+
+	if len(drCreatedIDs) == 0 {
+		t.Fatal("Discoveryrule create error: empty IDs array")
+	}
+
+	t.Logf("Discoveryrule create: success")
+
+	return drCreatedIDs
+}
+
+func testDiscoveryruleDelete(t *testing.T, z Context, drCreatedIDs []int) []int {
+
+	drDeletedIDs, _, err := z.DiscoveryruleDelete(drCreatedIDs)
+	if err != nil {
+		t.Fatal("Discoveryrule delete error:", err)
+	}
+
+	if len(drDeletedIDs) == 0 {
+		t.Fatal("Discoveryrule delete error: empty IDs array")
+	}
+
+	if reflect.DeepEqual(drDeletedIDs, drCreatedIDs) == false {
+		t.Fatal("Discoveryrule delete error: IDs arrays for created and deleted discoveryrule are mismatch")
+	}
+
+	t.Logf("Discoveryrule delete: success")
+
+	return drDeletedIDs
+}
+
+func testDiscoveryruleGet(t *testing.T, z Context, drCreatedIDs []int) []DiscoveryruleObject {
+
+	drObjects, _, err := z.DiscoveryruleGet(DiscoveryruleGetParams{
+		ItemIDs:      drCreatedIDs,
+		SelectFilter: SelectExtendedOutput,
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+
+	if err != nil {
+		t.Error("Discoveryrule get error:", err)
+	} else {
+		if len(drObjects) == 0 {
+			t.Error("Discoveryrule get error: unable to find created discoveryrule")
+		} else {
+
+			f := drObjects[0].Filter
+
+			if f == nil || f.EvalType != DiscoveryruleFilterEvalTypeAnd || len(f.Conditions) != 2 {
+				t.Error("Discoveryrule get error: filter mismatch")
+			}
+
+			t.Logf("Discoveryrule get: success")
+		}
+	}
+
+	return drObjects
+}
@@ -0,0 +1,429 @@
+package zabbix
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// For `EventObject` field: `Source`
+const (
+	EventSourceTrigger          = 0
+	EventSourceDiscovery        = 1
+	EventSourceAutoregistration = 2
+	EventSourceInternal         = 3
+)
+
+// For `EventObject` field: `Object`
+const (
+	EventObjectTrigger            = 0
+	EventObjectDiscoveredHost     = 1
+	EventObjectDiscoveredService  = 2
+	EventObjectAutoregisteredHost = 3
+	EventObjectItem               = 4
+	EventObjectLLDRule            = 5
+)
+
+// For `EventObject` field: `Value`
+const (
+	EventValueOK      = 0
+	EventValueProblem = 1
+)
+
+// For `EventObject` field: `Severity`
+const (
+	EventSeverityNotClassified = 0
+	EventSeverityInformation   = 1
+	EventSeverityWarning       = 2
+	EventSeverityAverage       = 3
+	EventSeverityHigh          = 4
+	EventSeverityDisaster      = 5
+)
+
+// For `EventObject` field: `Acknowledged`
+const (
+	EventAcknowledgedFalse = 0
+	EventAcknowledgedTrue  = 1
+)
+
+// For `EventObject` field: `Suppressed`
+const (
+	EventSuppressedFalse = 0
+	EventSuppressedTrue  = 1
+)
+
+// For `EventGetParams` field: `Evaltype`
+const (
+	EventEvaltypeAndOr = 0
+	EventEvaltypeOr    = 2
+)
+
+// For `EventTagObject` field: `Operator`
+const (
+	EventTagOperatorContains = 0
+	EventTagOperatorEquals   = 1
+)
+
+// For `EventAcknowledgeParams` field: `Action` (bitmask)
+const (
+	EventAcknowledgeActionClose          = 0x01
+	EventAcknowledgeActionAcknowledge    = 0x02
+	EventAcknowledgeActionMessage        = 0x04
+	EventAcknowledgeActionChangeSeverity = 0x08
+	EventAcknowledgeActionUnacknowledge  = 0x10
+	EventAcknowledgeActionSuppress       = 0x20
+	EventAcknowledgeActionUnsuppress     = 0x40
+)
+
+// Zabbix API version manual problem suppression is introduced in
+const eventSuppressVersion = "6.2"
+
+// EventObject struct is used to store event operations results
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/event/object#event
+type EventObject struct {
+	EventID       int    `json:"eventid,omitempty"`
+	Source        int    `json:"source,omitempty"` // has defined consts, see above
+	Object        int    `json:"object,omitempty"` // has defined consts, see above
+	ObjectID      int    `json:"objectid,omitempty"`
+	Acknowledged  int    `json:"acknowledged,omitempty"` // has defined consts, see above
+	Clock         int    `json:"clock,omitempty"`
+	NS            int    `json:"ns,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Value         int    `json:"value,omitempty"`    // has defined consts, see above
+	Severity      int    `json:"severity,omitempty"` // has defined consts, see above, see also `HasSeverity()`
+	REventID      int    `json:"r_eventid,omitempty"`
+	CEventID      int    `json:"c_eventid,omitempty"`
+	CorrelationID int    `json:"correlationid,omitempty"`
+	UserID        int    `json:"userid,omitempty"`
+	Suppressed    int    `json:"suppressed,omitempty"` // has defined consts, see above
+
+	Acknowledges []EventAcknowledgeObject `json:"acknowledges,omitempty"`
+	Hosts        []HostObject             `json:"hosts,omitempty"`
+	Tags         []EventTagObject         `json:"tags,omitempty"`
+
+	// Trigger is the related object (see `EventGetParams` field `SelectRelatedObject`) of the trigger
+	// event. For other events (`Object` is not `EventObjectTrigger`) it is always nil
+	Trigger *TriggerObject `json:"relatedObject,omitempty"`
+}
+
+// EventAcknowledgeObject struct is used to store event update (acknowledgement) made by user
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/event/object#acknowledge
+type EventAcknowledgeObject struct {
+	AcknowledgeID int    `json:"acknowledgeid,omitempty"`
+	UserID        int    `json:"userid,omitempty"`
+	EventID       int    `json:"eventid,omitempty"`
+	Clock         int    `json:"clock,omitempty"`
+	Message       string `json:"message,omitempty"`
+	Action        int    `json:"action,omitempty"`       // see `EventAcknowledgeAction*` consts
+	OldSeverity   int    `json:"old_severity,omitempty"` // see `EventSeverity*` consts
+	NewSeverity   int    `json:"new_severity,omitempty"` // see `EventSeverity*` consts
+}
+
+// Labels of the `EventAcknowledgeObject` field `Action` bits, see `Actions()`
+var eventAcknowledgeActionLabels = []struct {
+	action int
+	label  string
+}{
+	{action: EventAcknowledgeActionClose, label: "closed"},
+	{action: EventAcknowledgeActionAcknowledge, label: "acknowledged"},
+	{action: EventAcknowledgeActionMessage, label: "message added"},
+	{action: EventAcknowledgeActionChangeSeverity, label: "severity changed"},
+	{action: EventAcknowledgeActionUnacknowledge, label: "unacknowledged"},
+	{action: EventAcknowledgeActionSuppress, label: "suppressed"},
+	{action: EventAcknowledgeActionUnsuppress, label: "unsuppressed"},
+}
+
+// EventTagObject struct is used to store event tag
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/event/object#event_tag
+type EventTagObject struct {
+	Tag   string `json:"tag,omitempty"`
+	Value string `json:"value,omitempty"`
+
+	Operator int `json:"operator,omitempty"` // Used for `get` operations, has defined consts, see above
+}
+
+// EventGetParams struct is used for event get requests
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/event/get#parameters
+type EventGetParams struct {
+	GetParameters
+
+	EventIDs        []int            `json:"eventids,omitempty"`
+	GroupIDs        []int            `json:"groupids,omitempty"`
+	HostIDs         []int            `json:"hostids,omitempty"`
+	ObjectIDs       []int            `json:"objectids,omitempty"`
+	ApplicationIDs  []int            `json:"applicationids,omitempty"`
+	Source          int              `json:"source,omitempty"` // has defined consts, see above
+	Object          int              `json:"object,omitempty"` // has defined consts, see above
+	Acknowledged    bool             `json:"acknowledged,omitempty"`
+	Suppressed      bool             `json:"suppressed,omitempty"`
+	Severities      []int            `json:"severities,omitempty"`
+	Evaltype        int              `json:"evaltype,omitempty"` // has defined consts, see above
+	Tags            []EventTagObject `json:"tags,omitempty"`
+	EventIDFrom     int              `json:"eventid_from,omitempty"`
+	EventIDTill     int              `json:"eventid_till,omitempty"`
+	TimeFrom        int              `json:"time_from,omitempty"`
+	TimeTill        int              `json:"time_till,omitempty"`
+	ProblemTimeFrom int              `json:"problem_time_from,omitempty"`
+	ProblemTimeTill int              `json:"problem_time_till,omitempty"`
+
+	// Value is a pointer to be able to filter by `EventValueOK` (zero) value, see `EventValue*` consts
+	Value *int `json:"value,omitempty"`
+
+	SelectHosts         SelectQuery `json:"selectHosts,omitempty"`
+	SelectRelatedObject SelectQuery `json:"selectRelatedObject,omitempty"` // `object` field is required in output
+	// SelectAlerts          SelectQuery `json:"select_alerts,omitempty"` // not implemented yet
+	SelectAcknowledges SelectQuery `json:"select_acknowledges,omitempty"`
+	SelectTags         SelectQuery `json:"selectTags,omitempty"`
+	// SelectSuppressionData SelectQuery `json:"selectSuppressionData,omitempty"` // not implemented yet
+}
+
+// EventAcknowledgeParams struct is used for event acknowledge requests
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/event/acknowledge#parameters
+type EventAcknowledgeParams struct {
+	EventIDs      []int  `json:"eventids"`
+	Action        int    `json:"action"` // has defined consts, see above
+	Message       string `json:"message,omitempty"`
+	Severity      *int   `json:"severity,omitempty"`       // see `EventSeverity*` consts
+	SuppressUntil int    `json:"suppress_until,omitempty"` // Used for `EventAcknowledgeActionSuppress` action, zero means indefinitely
+}
+
+// Structure to store acknowledge result
+type eventAcknowledgeResult struct {
+	EventIDs []int `json:"eventids"`
+}
+
+// Actions returns labels of the actions performed by the event update (e.g. `acknowledged`, `message added`),
+// since a single update may combine several actions
+func (a *EventAcknowledgeObject) Actions() []string {
+
+	r := []string{}
+
+	for _, l := range eventAcknowledgeActionLabels {
+		if a.Action&l.action != 0 {
+			r = append(r, l.label)
+		}
+	}
+
+	return r
+}
+
+// HasSeverity checks the event severity is meaningful. Only trigger events (`EventSourceTrigger`)
+// carry severity (of the trigger, possibly changed by acknowledgement). For discovery, autoregistration
+// and internal events Zabbix does not send severity or sends it as zero, so `Severity` field is
+// `EventSeverityNotClassified` for them
+func (e *EventObject) HasSeverity() bool {
+
+	return e.Source == EventSourceTrigger
+}
+
+// OnlyProblems makes `EventGet` to return only problem events (events of transition to problem state),
+// recovery events are excluded
+func (p *EventGetParams) OnlyProblems() {
+
+	v := EventValueProblem
+	p.Value = &v
+}
+
+// EventGet gets events
+func (z *Context) EventGet(params EventGetParams) ([]EventObject, int, error) {
+
+	var result []EventObject
+
+	status, err := z.request("event.get", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	// Related objects of non-trigger events are discovered hosts, items, etc.
+	for i := range result {
+		if result[i].Object != EventObjectTrigger {
+			result[i].Trigger = nil
+		}
+	}
+
+	return result, status, nil
+}
+
+// EventAcknowledge updates events (acknowledges, closes, suppresses them, etc.)
+func (z *Context) EventAcknowledge(params EventAcknowledgeParams) ([]int, int, error) {
+
+	var result eventAcknowledgeResult
+
+	status, err := z.request("event.acknowledge", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.EventIDs, status, nil
+}
+
+// SuppressProblems manually suppresses the problems until specified time, zero `until` means
+// indefinitely. Manual suppression is available since Zabbix 6.2
+func (z *Context) SuppressProblems(eventIDs []int, until time.Time) ([]int, error) {
+
+	params := EventAcknowledgeParams{
+		EventIDs: eventIDs,
+		Action:   EventAcknowledgeActionSuppress,
+	}
+
+	if until.IsZero() == false {
+		if until.Before(time.Now()) == true {
+			return nil, errors.New("suppression time is in the past")
+		}
+		params.SuppressUntil = int(until.Unix())
+	}
+
+	return z.eventSuppressionSet(params)
+}
+
+// UnsuppressProblems cancels manual suppression of the problems.
+// Manual suppression is available since Zabbix 6.2
+func (z *Context) UnsuppressProblems(eventIDs []int) ([]int, error) {
+
+	return z.eventSuppressionSet(EventAcknowledgeParams{
+		EventIDs: eventIDs,
+		Action:   EventAcknowledgeActionUnsuppress,
+	})
+}
+
+func (z *Context) eventSuppressionSet(params EventAcknowledgeParams) ([]int, error) {
+
+	if len(params.EventIDs) == 0 {
+		return nil, errors.New("no event ids specified")
+	}
+
+	if err := z.apiVersionRequire(eventSuppressVersion, "manual problem suppression"); err != nil {
+		return nil, err
+	}
+
+	eventIDs, _, err := z.EventAcknowledge(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return eventIDs, nil
+}
+
+// GetAllEvents gets all events matching `params` page by page with `pageSize` events per page.
+// Events are sorted by `eventid` in ascending order and each next page is requested starting
+// from the event following the last received one. Event IDs grow monotonically, so events created
+// during paging do not cause gaps or duplicates (unlike paging with offsets).
+// `SortField`, `SortOrder` and `Limit` of `params` are overridden. If `Output` is set to fields list,
+// `eventid` field is added to it, since it is required to request the next page
+func (z *Context) GetAllEvents(params EventGetParams, pageSize int) ([]EventObject, error) {
+
+	if pageSize <= 0 {
+		return nil, errors.New("page size must be positive")
+	}
+
+	if fields, b := params.Output.(SelectFields); b == true {
+
+		found := false
+		for _, f := range fields {
+			if f == "eventid" {
+				found = true
+			}
+		}
+
+		if found == false {
+			params.Output = SelectFields(append(append([]string{}, fields...), "eventid"))
+		}
+	}
+
+	params.SortField = []string{"eventid"}
+	params.SortOrder = []string{GetParametersSortOrderASC}
+	params.Limit = pageSize
+
+	r := []EventObject{}
+
+	for {
+
+		eObjects, _, err := z.EventGet(params)
+		if err != nil {
+			return nil, err
+		}
+
+		r = append(r, eObjects...)
+
+		if len(eObjects) < pageSize {
+			break
+		}
+
+		// Protects from endless paging if event IDs are not returned
+		next := eObjects[len(eObjects)-1].EventID + 1
+		if next <= params.EventIDFrom {
+			return nil, fmt.Errorf("get events error: unexpected event id %d on page starting from %d", next-1, params.EventIDFrom)
+		}
+
+		params.EventIDFrom = next
+	}
+
+	return r, nil
+}
+
+// GetFlappingTriggers counts value transitions (OK -> PROBLEM and PROBLEM -> OK) of each trigger
+// within the specified time window and returns the triggers with more than `minFlaps` transitions.
+// Result is a map of trigger ID to its transitions count. The first event of the trigger within the window
+// is not a transition, since trigger value before the window is unknown.
+//
+// Consecutive events with the same value (e.g. for triggers in multiple problem events generation mode)
+// are not counted as transitions.
+func (z *Context) GetFlappingTriggers(from, to time.Time, minFlaps int) (map[int]int, error) {
+
+	eObjects, _, err := z.EventGet(EventGetParams{
+		Source:   EventSourceTrigger,
+		Object:   EventObjectTrigger,
+		TimeFrom: int(from.Unix()),
+		TimeTill: int(to.Unix()),
+		GetParameters: GetParameters{
+			Output: SelectFields{"eventid", "objectid", "clock", "value"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return countTriggerFlaps(eObjects, minFlaps), nil
+}
+
+func countTriggerFlaps(events []EventObject, minFlaps int) map[int]int {
+
+	byTrigger := make(map[int][]EventObject)
+	for _, e := range events {
+		byTrigger[e.ObjectID] = append(byTrigger[e.ObjectID], e)
+	}
+
+	r := make(map[int]int)
+
+	for triggerID, te := range byTrigger {
+
+		sort.Slice(te, func(i, j int) bool {
+			if te[i].Clock != te[j].Clock {
+				return te[i].Clock < te[j].Clock
+			}
+			return te[i].EventID < te[j].EventID
+		})
+
+		flaps := 0
+		prev := te[0].Value
+
+		for _, e := range te[1:] {
+			if e.Value != prev {
+				flaps++
+			}
+			prev = e.Value
+		}
+
+		if flaps > minFlaps {
+			r[triggerID] = flaps
+		}
+	}
+
+	return r
+}
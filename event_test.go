@@ -0,0 +1,512 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const (
+	testEventFlappingTriggerID = 100
+	testEventStableTriggerID   = 200
+)
+
+func TestEventFlappingTriggers(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "event.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return []map[string]interface{}{
+			{"eventid": "7", "objectid": "100", "clock": "1070", "value": "1"},
+			{"eventid": "4", "objectid": "100", "clock": "1040", "value": "0"},
+			{"eventid": "1", "objectid": "100", "clock": "1010", "value": "1"},
+			{"eventid": "3", "objectid": "100", "clock": "1030", "value": "1"},
+			{"eventid": "2", "objectid": "100", "clock": "1020", "value": "0"},
+			{"eventid": "5", "objectid": "200", "clock": "1050", "value": "1"},
+			{"eventid": "6", "objectid": "200", "clock": "1060", "value": "1"},
+		}, nil
+	})
+	defer closeMock()
+
+	flapping, err := z.GetFlappingTriggers(time.Unix(1000, 0), time.Unix(2000, 0), 3)
+	if err != nil {
+		t.Fatal("Event flapping triggers get error:", err)
+	}
+
+	if reflect.DeepEqual(flapping, map[int]int{testEventFlappingTriggerID: 4}) == false {
+		t.Fatalf("Event flapping triggers get error: unexpected result %v", flapping)
+	}
+
+	// Triggers must exceed `minFlaps`
+	flapping, err = z.GetFlappingTriggers(time.Unix(1000, 0), time.Unix(2000, 0), 4)
+	if err != nil {
+		t.Fatal("Event flapping triggers get error:", err)
+	}
+
+	if len(flapping) != 0 {
+		t.Errorf("Event flapping triggers get error: unexpected result on boundary %v", flapping)
+	}
+
+	// Single problem event is not a transition
+	if r := countTriggerFlaps([]EventObject{{EventID: 1, ObjectID: 300, Clock: 1000, Value: 1}}, 0); len(r) != 0 {
+		t.Errorf("Event flapping triggers get error: unexpected result for single event %v", r)
+	}
+
+	t.Logf("Event flapping triggers get: success")
+}
+
+func TestEventProblemsSuppression(t *testing.T) {
+
+	var sent EventAcknowledgeParams
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "apiinfo.version":
+			return "6.2.0", nil
+		case "event.acknowledge":
+
+			sent = EventAcknowledgeParams{}
+			if err := json.Unmarshal(params, &sent); err != nil {
+				return nil, err
+			}
+
+			var ids []string
+			for _, id := range sent.EventIDs {
+				ids = append(ids, fmt.Sprintf("%d", id))
+			}
+
+			return map[string]interface{}{"eventids": ids}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	// Suppress until
+	eventIDs, err := z.SuppressProblems([]int{11, 12}, until)
+	if err != nil {
+		t.Fatal("Event problems suppression error:", err)
+	}
+
+	if reflect.DeepEqual(eventIDs, []int{11, 12}) == false ||
+		sent.Action != EventAcknowledgeActionSuppress ||
+		sent.SuppressUntil != int(until.Unix()) {
+		t.Errorf("Event problems suppression error: unexpected suppress params %+v", sent)
+	}
+
+	// Unsuppress
+	eventIDs, err = z.UnsuppressProblems([]int{11})
+	if err != nil {
+		t.Fatal("Event problems suppression error:", err)
+	}
+
+	if reflect.DeepEqual(eventIDs, []int{11}) == false ||
+		sent.Action != EventAcknowledgeActionUnsuppress ||
+		sent.SuppressUntil != 0 {
+		t.Errorf("Event problems suppression error: unexpected unsuppress params %+v", sent)
+	}
+
+	// Older version
+	z.apiVersion = "5.0.2"
+
+	if _, err := z.UnsuppressProblems([]int{11}); err == nil {
+		t.Error("Event problems suppression error: expected version error")
+	}
+
+	t.Logf("Event problems suppression: success")
+}
+
+func TestEventGetAll(t *testing.T) {
+
+	var (
+		events = []int{1, 2, 3, 5, 6, 7, 8}
+		pages  int
+	)
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p EventGetParams
+
+		if method != "event.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		if reflect.DeepEqual(p.SortField, []string{"eventid"}) == false || p.Limit != 3 {
+			return nil, fmt.Errorf("unexpected params %s", string(params))
+		}
+
+		r := []map[string]interface{}{}
+		for _, id := range events {
+			if id >= p.EventIDFrom && len(r) < p.Limit {
+				r = append(r, map[string]interface{}{"eventid": fmt.Sprintf("%d", id)})
+			}
+		}
+
+		// New event is created after the first page is sent
+		pages++
+		if pages == 1 {
+			events = append(events, 9)
+		}
+
+		return r, nil
+	})
+	defer closeMock()
+
+	eObjects, err := z.GetAllEvents(EventGetParams{}, 3)
+	if err != nil {
+		t.Fatal("Event get all error:", err)
+	}
+
+	var ids []int
+	for _, e := range eObjects {
+		ids = append(ids, e.EventID)
+	}
+
+	if reflect.DeepEqual(ids, []int{1, 2, 3, 5, 6, 7, 8, 9}) == false || pages != 3 {
+		t.Errorf("Event get all error: unexpected events %v (pages: %d)", ids, pages)
+	}
+
+	t.Logf("Event get all: success")
+}
+
+func TestEventGetAllOutputFields(t *testing.T) {
+
+	var pages int
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p struct {
+			Output      []string `json:"output"`
+			EventIDFrom int      `json:"eventid_from"`
+		}
+
+		if method != "event.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		pages++
+		if pages > 3 {
+			return nil, fmt.Errorf("too many pages")
+		}
+
+		// Event IDs are returned only if requested, as Zabbix does
+		withID := false
+		for _, f := range p.Output {
+			if f == "eventid" {
+				withID = true
+			}
+		}
+
+		if p.EventIDFrom == 0 {
+			p.EventIDFrom = 1
+		}
+
+		r := []map[string]interface{}{}
+		for id := p.EventIDFrom; id < 4 && len(r) < 2; id++ {
+			e := map[string]interface{}{"clock": fmt.Sprintf("%d", 1000+id)}
+			if withID == true {
+				e["eventid"] = fmt.Sprintf("%d", id)
+			}
+			r = append(r, e)
+		}
+
+		return r, nil
+	})
+	defer closeMock()
+
+	eObjects, err := z.GetAllEvents(EventGetParams{
+		GetParameters: GetParameters{
+			Output: SelectFields{"clock"},
+		},
+	}, 2)
+	if err != nil {
+		t.Fatal("Event get all output fields error:", err)
+	}
+
+	var ids []int
+	for _, e := range eObjects {
+		ids = append(ids, e.EventID)
+	}
+
+	if reflect.DeepEqual(ids, []int{1, 2, 3}) == false || pages != 2 {
+		t.Errorf("Event get all output fields error: unexpected events %v (pages: %d)", ids, pages)
+	}
+
+	t.Logf("Event get all output fields: success")
+}
+
+func TestEventOnlyProblems(t *testing.T) {
+
+	var value json.RawMessage
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p struct {
+			Value json.RawMessage `json:"value"`
+		}
+
+		if method != "event.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		value = p.Value
+
+		events := []map[string]interface{}{
+			{"eventid": "1", "value": "1"},
+			{"eventid": "2", "value": "0"},
+		}
+
+		r := []map[string]interface{}{}
+		for _, e := range events {
+			if p.Value == nil || string(p.Value) == e["value"] {
+				r = append(r, e)
+			}
+		}
+
+		return r, nil
+	})
+	defer closeMock()
+
+	params := EventGetParams{}
+	params.OnlyProblems()
+
+	eObjects, _, err := z.EventGet(params)
+	if err != nil {
+		t.Fatal("Event only problems error:", err)
+	}
+
+	if string(value) != "1" {
+		t.Errorf("Event only problems error: unexpected value param %s", string(value))
+	}
+
+	if len(eObjects) != 1 || eObjects[0].EventID != 1 {
+		t.Errorf("Event only problems error: unexpected events %v", eObjects)
+	}
+
+	// Explicit OK value is sent
+	ok := EventValueOK
+
+	if _, _, err := z.EventGet(EventGetParams{Value: &ok}); err != nil {
+		t.Fatal("Event only problems error:", err)
+	}
+
+	if string(value) != "0" {
+		t.Errorf("Event only problems error: unexpected value param %s", string(value))
+	}
+
+	// Not set
+	if _, _, err := z.EventGet(EventGetParams{}); err != nil {
+		t.Fatal("Event only problems error:", err)
+	}
+
+	if value != nil {
+		t.Errorf("Event only problems error: unexpected value param %s", string(value))
+	}
+
+	t.Logf("Event only problems: success")
+}
+
+func TestEventSeverity(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "event.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return []map[string]interface{}{
+			{"eventid": "1", "source": "0", "object": "0", "severity": "4"},
+			{"eventid": "2", "source": "3", "object": "4", "severity": "0"},
+			{"eventid": "3", "source": "1", "object": "1", "severity": ""},
+			{"eventid": "4", "source": "2", "object": "3"},
+		}, nil
+	})
+	defer closeMock()
+
+	eObjects, _, err := z.EventGet(EventGetParams{})
+	if err != nil {
+		t.Fatal("Event severity error:", err)
+	}
+
+	if len(eObjects) != 4 {
+		t.Fatalf("Event severity error: unexpected events %v", eObjects)
+	}
+
+	tests := []struct {
+		name        string
+		severity    int
+		hasSeverity bool
+	}{
+		{name: "trigger", severity: EventSeverityHigh, hasSeverity: true},
+		{name: "internal", severity: EventSeverityNotClassified, hasSeverity: false},
+		{name: "discovery", severity: EventSeverityNotClassified, hasSeverity: false},
+		{name: "autoregistration", severity: EventSeverityNotClassified, hasSeverity: false},
+	}
+
+	for i, tt := range tests {
+
+		e := eObjects[i]
+
+		if e.Severity != tt.severity || e.HasSeverity() != tt.hasSeverity {
+			t.Errorf("Event severity error (%s): unexpected severity %d (%v)", tt.name, e.Severity, e.HasSeverity())
+		}
+	}
+
+	t.Logf("Event severity: success")
+}
+
+func TestEventRelatedObject(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "event.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		var p struct {
+			SelectHosts         []string `json:"selectHosts"`
+			SelectRelatedObject string   `json:"selectRelatedObject"`
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		if reflect.DeepEqual(p.SelectHosts, []string{"hostid", "name"}) == false || p.SelectRelatedObject != SelectExtendedOutput {
+			return nil, fmt.Errorf("unexpected params %s", string(params))
+		}
+
+		return []map[string]interface{}{
+			{
+				"eventid":  "1",
+				"source":   "0",
+				"object":   "0",
+				"objectid": "13491",
+				"hosts": []map[string]interface{}{
+					{"hostid": "10084", "name": "Zabbix server"},
+				},
+				"relatedObject": map[string]interface{}{
+					"triggerid":   "13491",
+					"description": "Zabbix agent is not available",
+					"priority":    "3",
+				},
+			},
+			{
+				"eventid":  "2",
+				"source":   "3",
+				"object":   "4",
+				"objectid": "23661",
+				"hosts": []map[string]interface{}{
+					{"hostid": "10084", "name": "Zabbix server"},
+				},
+				"relatedObject": map[string]interface{}{
+					"itemid": "23661",
+					"name":   "Free disk space",
+				},
+			},
+		}, nil
+	})
+	defer closeMock()
+
+	eObjects, _, err := z.EventGet(EventGetParams{
+		SelectHosts:         SelectFields{"hostid", "name"},
+		SelectRelatedObject: SelectExtendedOutput,
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		t.Fatal("Event related object error:", err)
+	}
+
+	if len(eObjects) != 2 {
+		t.Fatalf("Event related object error: unexpected events %v", eObjects)
+	}
+
+	e := eObjects[0]
+
+	if len(e.Hosts) != 1 || e.Hosts[0].HostID != 10084 || e.Hosts[0].Name != "Zabbix server" {
+		t.Errorf("Event related object error: unexpected hosts %v", e.Hosts)
+	}
+
+	if e.Trigger == nil || e.Trigger.TriggerID != 13491 || e.Trigger.Priority != TriggerPriorityAverage {
+		t.Errorf("Event related object error: unexpected trigger %v", e.Trigger)
+	}
+
+	if eObjects[1].Trigger != nil || len(eObjects[1].Hosts) != 1 {
+		t.Errorf("Event related object error: unexpected internal event %v", eObjects[1])
+	}
+
+	t.Logf("Event related object: success")
+}
+
+func TestEventAcknowledgeActions(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "event.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return []map[string]interface{}{
+			{
+				"eventid": "1",
+				"acknowledges": []map[string]interface{}{
+					{"acknowledgeid": "11", "userid": "1", "eventid": "1", "clock": "1591048800", "message": "On it", "action": "6"},
+					{"acknowledgeid": "12", "userid": "1", "eventid": "1", "clock": "1591052400", "message": "Fixed", "action": "13", "old_severity": "3", "new_severity": "4"},
+				},
+			},
+		}, nil
+	})
+	defer closeMock()
+
+	eObjects, _, err := z.EventGet(EventGetParams{
+		SelectAcknowledges: SelectExtendedOutput,
+	})
+	if err != nil {
+		t.Fatal("Event acknowledge actions error:", err)
+	}
+
+	if len(eObjects) != 1 || len(eObjects[0].Acknowledges) != 2 {
+		t.Fatalf("Event acknowledge actions error: unexpected events %v", eObjects)
+	}
+
+	tests := [][]string{
+		{"acknowledged", "message added"},
+		{"closed", "message added", "severity changed"},
+	}
+
+	for i, expected := range tests {
+
+		a := eObjects[0].Acknowledges[i]
+
+		if actions := a.Actions(); reflect.DeepEqual(actions, expected) == false {
+			t.Errorf("Event acknowledge actions error: unexpected actions %v for action %d", actions, a.Action)
+		}
+	}
+
+	if a := eObjects[0].Acknowledges[1]; a.OldSeverity != EventSeverityAverage || a.NewSeverity != EventSeverityHigh {
+		t.Errorf("Event acknowledge actions error: unexpected severities %v", a)
+	}
+
+	t.Logf("Event acknowledge actions: success")
+}
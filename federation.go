@@ -0,0 +1,83 @@
+package zabbix
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Federation is used to query several Zabbix servers uniformly. `Contexts` contains
+// logged in contexts keyed by server names
+type Federation struct {
+	Contexts map[string]*Context
+}
+
+// FederationError is returned by `Federation` methods if requests to some servers failed.
+// `Errors` contains errors keyed by server names
+type FederationError struct {
+	Errors map[string]error
+}
+
+func (e *FederationError) Error() string {
+
+	var names []string
+	for n := range e.Errors {
+		names = append(names, n)
+	}
+
+	sort.Strings(names)
+
+	var s []string
+	for _, n := range names {
+		s = append(s, fmt.Sprintf("%s: %v", n, e.Errors[n]))
+	}
+
+	return "federation request error: " + strings.Join(s, "; ")
+}
+
+// GetItemsAll gets items with `params` from all servers concurrently and returns them keyed by server names.
+// Each request is sent with the settings of the appropriate context (e.g. timeout of its `HTTPClient`).
+// If some requests failed, results of the other servers are returned with `*FederationError`
+func (f *Federation) GetItemsAll(params ItemGetParams) (map[string][]ItemObject, error) {
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	r := make(map[string][]ItemObject)
+	errs := make(map[string]error)
+
+	for name, z := range f.Contexts {
+
+		wg.Add(1)
+
+		go func(name string, z *Context) {
+
+			defer wg.Done()
+
+			iObjects, _, err := z.ItemGet(params)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs[name] = err
+				return
+			}
+
+			r[name] = iObjects
+		}(name, z)
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return r, &FederationError{
+			Errors: errs,
+		}
+	}
+
+	return r, nil
+}
@@ -0,0 +1,76 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFederationGetItemsAll(t *testing.T) {
+
+	handler := func(items []map[string]interface{}) testMockHandler {
+		return func(method string, params json.RawMessage) (interface{}, error) {
+
+			if method != "item.get" {
+				return nil, fmt.Errorf("unexpected method %s", method)
+			}
+
+			return items, nil
+		}
+	}
+
+	zEU, closeEU := testMockContext(t, handler([]map[string]interface{}{
+		{"itemid": "1001", "key_": "agent.ping"},
+	}))
+	defer closeEU()
+
+	zUS, closeUS := testMockContext(t, handler([]map[string]interface{}{
+		{"itemid": "2001", "key_": "agent.ping"},
+		{"itemid": "2002", "key_": "system.uptime"},
+	}))
+	defer closeUS()
+
+	zAsia, closeAsia := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+		return nil, fmt.Errorf("No permissions to referred object or it does not exist!")
+	})
+	defer closeAsia()
+
+	f := Federation{
+		Contexts: map[string]*Context{
+			"eu": zEU,
+			"us": zUS,
+		},
+	}
+
+	params := ItemGetParams{
+		GetParameters: GetParameters{
+			Output: SelectFields{"itemid", "key_"},
+		},
+	}
+
+	r, err := f.GetItemsAll(params)
+	if err != nil {
+		t.Fatal("Federation get items all error:", err)
+	}
+
+	if len(r) != 2 || len(r["eu"]) != 1 || r["eu"][0].ItemID != 1001 || len(r["us"]) != 2 || r["us"][1].ItemID != 2002 {
+		t.Fatalf("Federation get items all error: unexpected result %v", r)
+	}
+
+	// Failed server
+	f.Contexts["asia"] = zAsia
+
+	r, err = f.GetItemsAll(params)
+
+	var e *FederationError
+	if errors.As(err, &e) == false || len(e.Errors) != 1 || e.Errors["asia"] == nil {
+		t.Fatalf("Federation get items all error: unexpected error %v", err)
+	}
+
+	if len(r) != 2 {
+		t.Errorf("Federation get items all error: unexpected result %v", r)
+	}
+
+	t.Logf("Federation get items all: success")
+}
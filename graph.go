@@ -0,0 +1,228 @@
+package zabbix
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// For `GraphObject` field: `GraphType`
+const (
+	GraphTypeNormal   = 0
+	GraphTypeStacked  = 1
+	GraphTypePie      = 2
+	GraphTypeExploded = 3
+)
+
+// For `GraphObject` fields: `YMinType`, `YMaxType`
+const (
+	GraphYAxisTypeCalculated = 0
+	GraphYAxisTypeFixed      = 1
+	GraphYAxisTypeItem       = 2
+)
+
+// For `GraphItemObject` field: `DrawType`
+const (
+	GraphItemDrawTypeLine       = 0
+	GraphItemDrawTypeFilled     = 1
+	GraphItemDrawTypeBoldLine   = 2
+	GraphItemDrawTypeDot        = 3
+	GraphItemDrawTypeDashedLine = 4
+	GraphItemDrawTypeGradient   = 5
+)
+
+// For `GraphItemObject` field: `YAxisSide`
+const (
+	GraphItemYAxisSideLeft  = 0
+	GraphItemYAxisSideRight = 1
+)
+
+// For `GraphItemObject` field: `CalcFnc`
+const (
+	GraphItemCalcFncMin     = 1
+	GraphItemCalcFncAverage = 2
+	GraphItemCalcFncMax     = 4
+	GraphItemCalcFncAll     = 7
+	GraphItemCalcFncLast    = 9
+)
+
+// For `GraphItemObject` field: `Type`
+const (
+	GraphItemTypeSimple = 0
+	GraphItemTypeSum    = 2
+)
+
+// Graph item color format: six hexadecimal digits (e.g. `1A7C11`)
+var graphItemColorRegexp = regexp.MustCompile(`^[0-9A-Fa-f]{6}$`)
+
+// GraphObject struct is used to store graph operations results
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/graph/object#graph
+type GraphObject struct {
+	GraphID        int     `json:"graphid,omitempty"`
+	Height         int     `json:"height,omitempty"`
+	Name           string  `json:"name,omitempty"`
+	Width          int     `json:"width,omitempty"`
+	Flags          int     `json:"flags,omitempty"`
+	GraphType      int     `json:"graphtype,omitempty"` // has defined consts, see above
+	PercentLeft    float64 `json:"percent_left,omitempty"`
+	PercentRight   float64 `json:"percent_right,omitempty"`
+	Show3D         int     `json:"show_3d,omitempty"`
+	ShowLegend     int     `json:"show_legend,omitempty"`
+	ShowWorkPeriod int     `json:"show_work_period,omitempty"`
+	ShowTriggers   int     `json:"show_triggers,omitempty"`
+	TemplateID     int     `json:"templateid,omitempty"`
+	YAxisMax       float64 `json:"yaxismax,omitempty"`
+	YAxisMin       float64 `json:"yaxismin,omitempty"`
+	YMaxItemID     int     `json:"ymax_itemid,omitempty"`
+	YMaxType       int     `json:"ymax_type,omitempty"` // has defined consts, see above
+	YMinItemID     int     `json:"ymin_itemid,omitempty"`
+	YMinType       int     `json:"ymin_type,omitempty"` // has defined consts, see above
+
+	GraphItems []GraphItemObject `json:"gitems,omitempty"`
+}
+
+// GraphItemObject struct is used to store graph items
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/graphitem/object#graph_item
+type GraphItemObject struct {
+	GraphItemID int    `json:"gitemid,omitempty"`
+	Color       string `json:"color"` // six hexadecimal digits, e.g. `1A7C11`
+	ItemID      int    `json:"itemid"`
+	CalcFnc     int    `json:"calc_fnc,omitempty"` // has defined consts, see above
+	DrawType    int    `json:"drawtype"`           // has defined consts, see above
+	GraphID     int    `json:"graphid,omitempty"`
+	SortOrder   int    `json:"sortorder"`
+	Type        int    `json:"type,omitempty"` // has defined consts, see above
+	YAxisSide   int    `json:"yaxisside"`      // has defined consts, see above
+}
+
+// GraphGetParams struct is used for graph get requests
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/graph/get#parameters
+type GraphGetParams struct {
+	GetParameters
+
+	GraphIDs    []int `json:"graphids,omitempty"`
+	GroupIDs    []int `json:"groupids,omitempty"`
+	TemplateIDs []int `json:"templateids,omitempty"`
+	HostIDs     []int `json:"hostids,omitempty"`
+	ItemIDs     []int `json:"itemids,omitempty"`
+	Templated   bool  `json:"templated,omitempty"`
+	Inherited   bool  `json:"inherited,omitempty"`
+	ExpandName  bool  `json:"expandName,omitempty"`
+
+	// SelectGroups          SelectQuery `json:"selectGroups,omitempty"` // not implemented yet
+	// SelectTemplates       SelectQuery `json:"selectTemplates,omitempty"` // not implemented yet
+	// SelectHosts           SelectQuery `json:"selectHosts,omitempty"` // not implemented yet
+	// SelectItems           SelectQuery `json:"selectItems,omitempty"` // not implemented yet
+	SelectGraphItems SelectQuery `json:"selectGraphItems,omitempty"`
+	// SelectGraphDiscovery  SelectQuery `json:"selectGraphDiscovery,omitempty"` // not implemented yet
+	// SelectDiscoveryRule   SelectQuery `json:"selectDiscoveryRule,omitempty"` // not implemented yet
+}
+
+// Structure to store creation result
+type graphCreateResult struct {
+	GraphIDs []int `json:"graphids"`
+}
+
+// Structure to store deletion result
+type graphDeleteResult struct {
+	GraphIDs []int `json:"graphids"`
+}
+
+// Time format used by Zabbix frontend for `from` and `to` params
+const graphTimeFormat = "2006-01-02 15:04:05"
+
+// Frontend script rendering graph images
+const graphImageScript = "chart2.php"
+
+// GraphImageURL builds the Zabbix frontend URL of the graph image for specified time range.
+// `Context.FrontendURL` must be set. Frontend scripts do not accept API session key, so the image
+// must be requested with the frontend session (e.g. the session cookie of the frontend user)
+func (z *Context) GraphImageURL(graphID int, from, to time.Time, width int) (string, error) {
+
+	if z.FrontendURL == "" {
+		return "", errors.New("frontend url is not set")
+	}
+
+	if graphID <= 0 {
+		return "", errors.New("wrong graph id")
+	}
+
+	if to.Before(from) == true {
+		return "", errors.New("wrong time range: `to` is before `from`")
+	}
+
+	if width <= 0 {
+		return "", errors.New("wrong graph width")
+	}
+
+	u, err := url.Parse(strings.TrimSuffix(z.FrontendURL, "/") + "/" + graphImageScript)
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	q.Set("graphid", strconv.Itoa(graphID))
+	q.Set("from", from.Format(graphTimeFormat))
+	q.Set("to", to.Format(graphTimeFormat))
+	q.Set("width", strconv.Itoa(width))
+	q.Set("profileIdx", "web.graphs.filter")
+
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// GraphGet gets graphs. Note that graph items are returned in `gitems` field
+// when `SelectGraphItems` is set
+func (z *Context) GraphGet(params GraphGetParams) ([]GraphObject, int, error) {
+
+	var result []GraphObject
+
+	status, err := z.request("graph.get", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result, status, nil
+}
+
+// GraphCreate creates graphs. Colors of graph items are validated before request
+func (z *Context) GraphCreate(params []GraphObject) ([]int, int, error) {
+
+	var result graphCreateResult
+
+	for _, g := range params {
+		for _, gi := range g.GraphItems {
+			if graphItemColorRegexp.MatchString(gi.Color) == false {
+				return nil, 0, fmt.Errorf("graph `%s` item %d has wrong color `%s`, six hexadecimal digits expected", g.Name, gi.ItemID, gi.Color)
+			}
+		}
+	}
+
+	status, err := z.request("graph.create", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.GraphIDs, status, nil
+}
+
+// GraphDelete deletes graphs
+func (z *Context) GraphDelete(graphIDs []int) ([]int, int, error) {
+
+	var result graphDeleteResult
+
+	status, err := z.request("graph.delete", graphIDs, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.GraphIDs, status, nil
+}
@@ -0,0 +1,129 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGraphImageURL(t *testing.T) {
+
+	z := Context{
+		FrontendURL: "https://zabbix.example.com/",
+	}
+
+	from := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	s, err := z.GraphImageURL(512, from, to, 800)
+	if err != nil {
+		t.Fatal("Graph image url error:", err)
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		t.Fatal("Graph image url error:", err)
+	}
+
+	if u.Path != "/chart2.php" {
+		t.Errorf("Graph image url error: unexpected path %s", u.Path)
+	}
+
+	q := u.Query()
+
+	if q.Get("graphid") != "512" ||
+		q.Get("from") != "2020-06-01 10:00:00" ||
+		q.Get("to") != "2020-06-01 11:00:00" ||
+		q.Get("width") != "800" {
+		t.Errorf("Graph image url error: unexpected query %s", u.RawQuery)
+	}
+
+	// Frontend URL is not set
+	if _, err := (&Context{}).GraphImageURL(512, from, to, 800); err == nil {
+		t.Error("Graph image url error: expected error for undefined frontend url")
+	}
+
+	t.Logf("Graph image url: success")
+}
+
+func TestGraphItems(t *testing.T) {
+
+	var created []map[string]interface{}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "graph.create":
+			if err := json.Unmarshal(params, &created); err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"graphids": []string{"700"}}, nil
+		case "graph.get":
+			g := created[0]
+			g["graphid"] = "700"
+			return []map[string]interface{}{g}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	gitems := []GraphItemObject{
+		{
+			ItemID:    1001,
+			Color:     "1A7C11",
+			DrawType:  GraphItemDrawTypeLine,
+			SortOrder: 0,
+			YAxisSide: GraphItemYAxisSideLeft,
+		},
+		{
+			ItemID:    1002,
+			Color:     "F63100",
+			DrawType:  GraphItemDrawTypeFilled,
+			SortOrder: 1,
+			YAxisSide: GraphItemYAxisSideRight,
+		},
+	}
+
+	// Wrong color
+	if _, _, err := z.GraphCreate([]GraphObject{
+		{
+			Name:       "testGraph",
+			GraphItems: []GraphItemObject{{ItemID: 1001, Color: "red"}},
+		},
+	}); err == nil {
+		t.Fatal("Graph items error: expected color validation error")
+	}
+
+	gCreatedIDs, _, err := z.GraphCreate([]GraphObject{
+		{
+			Name:       "testGraph",
+			Width:      900,
+			Height:     200,
+			GraphItems: gitems,
+		},
+	})
+	if err != nil {
+		t.Fatal("Graph items error:", err)
+	}
+
+	gObjects, _, err := z.GraphGet(GraphGetParams{
+		GraphIDs:         gCreatedIDs,
+		SelectGraphItems: SelectExtendedOutput,
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		t.Fatal("Graph items error:", err)
+	}
+
+	if len(gObjects) != 1 || reflect.DeepEqual(gObjects[0].GraphItems, gitems) == false {
+		t.Errorf("Graph items error: unexpected result %v", gObjects)
+	}
+
+	t.Logf("Graph items: success")
+}
@@ -1,6 +1,14 @@
 package zabbix
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// HistoryDefaultLimit is used by `HistoryGet()` if `Limit` is not set in params.
+// History of a busy item may contain millions of records, so request is always limited
+const HistoryDefaultLimit = 100000
 
 // For `HistoryGetParams` field: `History`
 const (
@@ -67,6 +75,18 @@ type HistoryLogObject struct {
 	Value      string `json:"value,omitempty"`
 }
 
+// HistoryPoint struct is used to store history record of any type with value kept as is.
+// `Value` must be interpreted according to the history type (see `HistoryObjectType*` consts)
+type HistoryPoint struct {
+	ItemID int    `json:"itemid"`
+	Clock  int    `json:"clock"`
+	Value  string `json:"value"`
+	NS     int    `json:"ns"`
+}
+
+// ErrHistoryNotFound is returned by `GetHistory()` if no history records are found
+var ErrHistoryNotFound = errors.New("history not found")
+
 // HistoryGetParams struct is used for history get requests
 //
 // see: https://www.zabbix.com/documentation/5.0/manual/api/reference/history/get#parameters
@@ -82,7 +102,8 @@ type HistoryGetParams struct {
 	Sortfield string `json:"sortfield,omitempty"`
 }
 
-// HistoryGet gets history
+// HistoryGet gets history. If `Limit` is not set, `HistoryDefaultLimit` is used.
+// `OnHistoryTruncated` hook of the context is called if the limit is reached
 func (z *Context) HistoryGet(params HistoryGetParams) (interface{}, int, error) {
 
 	var result interface{}
@@ -102,10 +123,59 @@ func (z *Context) HistoryGet(params HistoryGetParams) (interface{}, int, error)
 		return nil, 0, fmt.Errorf("Unknown history type")
 	}
 
-	status, err := z.request("history.get", params, result)
+	status, err := z.historyRequest(params, result)
 	if err != nil {
 		return nil, status, err
 	}
 
 	return result, status, nil
 }
+
+// GetHistory gets history records of items with the `valueType` (see `ItemValueType*` consts).
+// Zabbix API stores values of each item value type in a separate table, so the value type is a required
+// argument and must match value type of the items. `History` of `params` is overridden with the table
+// of the value type (see `ValueType.HistoryTable()`), so Zabbix default is never used implicitly.
+// `ErrHistoryNotFound` is returned if no records are found
+func (z *Context) GetHistory(valueType ValueType, params HistoryGetParams) ([]HistoryPoint, error) {
+
+	var result []HistoryPoint
+
+	h, err := valueType.HistoryTable()
+	if err != nil {
+		return nil, fmt.Errorf("unknown history type %d", valueType)
+	}
+
+	params.History = h
+
+	if _, err := z.historyRequest(params, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, ErrHistoryNotFound
+	}
+
+	return result, nil
+}
+
+// historyRequest sends `history.get` request with `HistoryDefaultLimit` applied if `Limit` is not set
+// and calls `OnHistoryTruncated` hook if the limit is reached. `result` must be a pointer to slice
+func (z *Context) historyRequest(params HistoryGetParams, result interface{}) (int, error) {
+
+	if params.Limit == 0 {
+		params.Limit = HistoryDefaultLimit
+	}
+
+	status, err := z.request("history.get", params, result)
+	if err != nil {
+		return status, err
+	}
+
+	if z.OnHistoryTruncated != nil {
+		if count := reflect.ValueOf(result).Elem().Len(); count >= params.Limit {
+			z.OnHistoryTruncated(params, count)
+		}
+	}
+
+	return status, nil
+}
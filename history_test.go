@@ -1,6 +1,9 @@
 package zabbix
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -21,6 +24,168 @@ func TestHistoryCRUD(t *testing.T) {
 	testHistoryGet(t, z)
 }
 
+func TestHistoryLimit(t *testing.T) {
+
+	var (
+		limit     int
+		truncated int
+	)
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p HistoryGetParams
+
+		if method != "history.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		limit = p.Limit
+
+		// Mock returns exactly 3 records
+		return []map[string]interface{}{
+			{"itemid": "1", "clock": "1600000000", "value": "1.5"},
+			{"itemid": "1", "clock": "1600000060", "value": "2.5"},
+			{"itemid": "1", "clock": "1600000120", "value": "3.5"},
+		}, nil
+	})
+	defer closeMock()
+
+	z.OnHistoryTruncated = func(params HistoryGetParams, count int) {
+		truncated = count
+	}
+
+	// Default limit
+	if _, _, err := z.HistoryGet(HistoryGetParams{History: HistoryObjectTypeFloat}); err != nil {
+		t.Fatal("History limit error:", err)
+	}
+
+	if limit != HistoryDefaultLimit {
+		t.Errorf("History limit error: expected default limit %d, got %d", HistoryDefaultLimit, limit)
+	}
+
+	if truncated != 0 {
+		t.Errorf("History limit error: unexpected truncation hook call")
+	}
+
+	// Limit is reached
+	hObjects, _, err := z.HistoryGet(HistoryGetParams{
+		History: HistoryObjectTypeFloat,
+		GetParameters: GetParameters{
+			Limit: 3,
+		},
+	})
+	if err != nil {
+		t.Fatal("History limit error:", err)
+	}
+
+	if limit != 3 {
+		t.Errorf("History limit error: expected limit 3, got %d", limit)
+	}
+
+	if len(*hObjects.(*[]HistoryFloatObject)) != 3 || truncated != 3 {
+		t.Errorf("History limit error: expected truncation hook call with 3 records, got %d", truncated)
+	}
+
+	t.Logf("History limit: success")
+}
+
+func TestHistoryGetPoints(t *testing.T) {
+
+	records := map[int][]map[string]interface{}{
+		HistoryObjectTypeFloat: {
+			{"itemid": "1001", "clock": "1600000000", "ns": "100", "value": "0.25"},
+			{"itemid": "1001", "clock": "1600000060", "ns": "200", "value": "0.75"},
+		},
+		HistoryObjectTypeNumericUnsigned: {
+			{"itemid": "1002", "clock": "1600000000", "ns": "0", "value": "18446744073709551615"},
+		},
+		HistoryObjectTypeText: {
+			{"id": "1", "itemid": "1003", "clock": "1600000030", "ns": "0", "value": "Linux host 5.4.0"},
+		},
+	}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p HistoryGetParams
+
+		if method != "history.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		r := []map[string]interface{}{}
+		for _, h := range records[p.History] {
+			var clock int
+			fmt.Sscan(h["clock"].(string), &clock)
+			if (p.TimeFrom == 0 || clock >= p.TimeFrom) && (p.TimeTill == 0 || clock <= p.TimeTill) {
+				r = append(r, h)
+			}
+		}
+
+		return r, nil
+	})
+	defer closeMock()
+
+	// Numeric float
+	hPoints, err := z.GetHistory(ItemValueTypeFloat, HistoryGetParams{ItemIDs: []int{1001}})
+	if err != nil {
+		t.Fatal("History get points error (float):", err)
+	}
+
+	if len(hPoints) != 2 || hPoints[1] != (HistoryPoint{ItemID: 1001, Clock: 1600000060, Value: "0.75", NS: 200}) {
+		t.Errorf("History get points error (float): unexpected points %v", hPoints)
+	}
+
+	// Numeric unsigned
+	hPoints, err = z.GetHistory(ItemValueTypeNumericUnsigned, HistoryGetParams{ItemIDs: []int{1002}})
+	if err != nil {
+		t.Fatal("History get points error (unsigned):", err)
+	}
+
+	if len(hPoints) != 1 || hPoints[0].Value != "18446744073709551615" {
+		t.Errorf("History get points error (unsigned): unexpected points %v", hPoints)
+	}
+
+	// Text
+	hPoints, err = z.GetHistory(ItemValueTypeText, HistoryGetParams{ItemIDs: []int{1003}})
+	if err != nil {
+		t.Fatal("History get points error (text):", err)
+	}
+
+	if len(hPoints) != 1 || hPoints[0].ItemID != 1003 || hPoints[0].Value != "Linux host 5.4.0" {
+		t.Errorf("History get points error (text): unexpected points %v", hPoints)
+	}
+
+	// Time window
+	hPoints, err = z.GetHistory(ItemValueTypeFloat, HistoryGetParams{TimeFrom: 1600000030, TimeTill: 1600000090})
+	if err != nil {
+		t.Fatal("History get points error (time window):", err)
+	}
+
+	if len(hPoints) != 1 || hPoints[0].Clock != 1600000060 {
+		t.Errorf("History get points error (time window): unexpected points %v", hPoints)
+	}
+
+	// Empty result
+	if _, err := z.GetHistory(ItemValueTypeFloat, HistoryGetParams{TimeFrom: 1700000000}); errors.Is(err, ErrHistoryNotFound) == false {
+		t.Errorf("History get points error (empty): expected not found error, got %v", err)
+	}
+
+	// Unknown history type
+	if _, err := z.GetHistory(7, HistoryGetParams{}); err == nil {
+		t.Error("History get points error: expected error for unknown history type")
+	}
+
+	t.Logf("History get points: success")
+}
+
 func testHistoryGet(t *testing.T, z Context) []HistoryFloatObject {
 
 	r := []HistoryFloatObject{}
@@ -1,5 +1,15 @@
 package zabbix
 
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
 // For `HostObject` field: `Available`
 const (
 	HostAvailableUnknown     = 0
@@ -154,7 +164,20 @@ type HostObject struct {
 	InheritedTags   []HostTagObject       `json:"inheritedTags,omitempty"`
 	Macros          []UsermacroObject     `json:"macros,omitempty"`
 	Templates       []TemplateObject      `json:"templates,omitempty"`       // Used for `create` operations
+	TemplatesClear  []TemplateObject      `json:"templates_clear,omitempty"` // Used for `update` operations
 	ParentTemplates []TemplateObject      `json:"parentTemplates,omitempty"` // Used to store result for `get` operations
+	HostDiscovery   *HostDiscoveryObject  `json:"hostDiscovery,omitempty"`   // Used to store result for `get` operations
+}
+
+// HostDiscoveryObject struct is used to store information about host prototype the host is discovered from
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/host/get#parameters
+type HostDiscoveryObject struct {
+	HostID       int    `json:"hostid,omitempty"`
+	ParentHostID int    `json:"parent_hostid,omitempty"` // ID of the host prototype
+	Host         string `json:"host,omitempty"`
+	LastCheck    int    `json:"lastcheck,omitempty"`
+	TSDelete     int    `json:"ts_delete,omitempty"`
 }
 
 // HostTagObject struct is used to store host tag
@@ -167,7 +190,9 @@ type HostTagObject struct {
 	Operator int `json:"operator,omitempty"` // Used for `get` operations, has defined consts, see above
 }
 
-// HostGetParams struct is used for host get requests
+// HostGetParams struct is used for host get requests. Different ID filters are combined with AND
+// semantics, e.g. `GroupIDs` and `TemplateIDs` set together return hosts within any of the groups
+// which are also linked to any of the templates (see `GetHostsInGroupWithTemplate()`)
 //
 // see: https://www.zabbix.com/documentation/5.0/manual/api/reference/host/get#parameters
 type HostGetParams struct {
@@ -211,8 +236,8 @@ type HostGetParams struct {
 	// SelectDiscoveries     SelectQuery `json:"selectDiscoveries,omitempty"` // not implemented yet
 	// SelectDiscoveryRule   SelectQuery `json:"selectDiscoveryRule ,omitempty"` // not implemented yet
 	// SelectGraphs          SelectQuery `json:"selectGraphs,omitempty"` // not implemented yet
-	SelectGroups SelectQuery `json:"selectGroups,omitempty"`
-	// SelectHostDiscovery   SelectQuery `json:"selectHostDiscovery ,omitempty"` // not implemented yet
+	SelectGroups        SelectQuery `json:"selectGroups,omitempty"`
+	SelectHostDiscovery SelectQuery `json:"selectHostDiscovery,omitempty"`
 	// SelectHTTPTests       SelectQuery `json:"selectHttpTests,omitempty"` // not implemented yet
 	SelectInterfaces SelectQuery `json:"selectInterfaces,omitempty"`
 	// SelectInventory       SelectQuery `json:"selectInventory,omitempty"` // not implemented yet
@@ -291,3 +316,508 @@ func (z *Context) HostDelete(hostIDs []int) ([]int, int, error) {
 
 	return result.HostIDs, status, nil
 }
+
+// CloneHost creates a copy of the host with the ID `sourceHostID`. The new host gets
+// the same groups, linked templates, macros and interfaces as the source one, but with
+// the name `newName` and IP `newIP` set for all its interfaces.
+//
+// Note: items, triggers and other entities inherited from linked templates are created
+// by Zabbix automatically, but host-local items are NOT cloned. Values of the secret
+// macros are not returned by API, so such macros are cloned with empty values.
+func (z *Context) CloneHost(sourceHostID int, newName string, newIP string) (int, error) {
+
+	hObjects, _, err := z.HostGet(HostGetParams{
+		HostIDs:               []int{sourceHostID},
+		SelectGroups:          SelectExtendedOutput,
+		SelectInterfaces:      SelectExtendedOutput,
+		SelectMacros:          SelectExtendedOutput,
+		SelectParentTemplates: SelectExtendedOutput,
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(hObjects) == 0 {
+		return 0, fmt.Errorf("source host with id %d not found", sourceHostID)
+	}
+
+	src := hObjects[0]
+
+	h := HostObject{
+		Host:          newName,
+		Description:   src.Description,
+		IpmiPassword:  src.IpmiPassword,
+		IpmiPrivilege: src.IpmiPrivilege,
+		IpmiUsername:  src.IpmiUsername,
+		ProxyHostID:   src.ProxyHostID,
+		Status:        src.Status,
+		TLSConnect:    src.TLSConnect,
+		TLSAccept:     src.TLSAccept,
+		TLSIssuer:     src.TLSIssuer,
+		TLSSubject:    src.TLSSubject,
+	}
+
+	for _, g := range src.Groups {
+		h.Groups = append(h.Groups, HostgroupObject{
+			GroupID: g.GroupID,
+		})
+	}
+
+	for _, t := range src.ParentTemplates {
+		h.Templates = append(h.Templates, TemplateObject{
+			TemplateID: t.TemplateID,
+		})
+	}
+
+	for _, m := range src.Macros {
+		h.Macros = append(h.Macros, UsermacroObject{
+			Macro:       m.Macro,
+			Value:       m.Value,
+			Type:        m.Type,
+			Description: m.Description,
+		})
+	}
+
+	for _, i := range src.Interfaces {
+		h.Interfaces = append(h.Interfaces, HostinterfaceObject{
+			DNS:     i.DNS,
+			IP:      newIP,
+			Main:    i.Main,
+			Port:    i.Port,
+			Type:    i.Type,
+			UseIP:   i.UseIP,
+			Details: i.Details,
+		})
+	}
+
+	// Map is used instead of `HostObject` to send zero (manual) inventory mode and
+	// zero (none) IPMI authtype, otherwise Zabbix defaults are applied to the clone
+	b, err := json.Marshal(h)
+	if err != nil {
+		return 0, err
+	}
+
+	var params map[string]interface{}
+	if err := json.Unmarshal(b, &params); err != nil {
+		return 0, err
+	}
+
+	params["inventory_mode"] = src.InventoryMode
+	params["ipmi_authtype"] = src.IpmiAuthtype
+
+	var result hostCreateResult
+
+	if _, err := z.request("host.create", []map[string]interface{}{params}, &result); err != nil {
+		return 0, err
+	}
+
+	if len(result.HostIDs) == 0 {
+		return 0, fmt.Errorf("host create error: empty IDs array")
+	}
+
+	return result.HostIDs[0], nil
+}
+
+// Key of the Zabbix internal item with the host agent availability
+const hostAvailabilityItemKey = "zabbix[host,agent,available]"
+
+// GetHostAvailabilityHistory gets the agent availability history of the host with ID `hostID` within
+// the specified time window (values are `HostAvailable*` consts). History is read from the Zabbix internal
+// item `zabbix[host,agent,available]`, so this item must be configured on the host (directly or via template),
+// otherwise an error is returned. History table is selected by the value type of the item, values of
+// the float item are truncated to integers.
+func (z *Context) GetHostAvailabilityHistory(hostID int, from, to time.Time) ([]HistoryIntegerObject, error) {
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{
+		HostIDs: []int{hostID},
+		GetParameters: GetParameters{
+			Filter: map[string]interface{}{
+				"key_": hostAvailabilityItemKey,
+			},
+			Output: SelectFields{"itemid", "value_type"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(iObjects) == 0 {
+		return nil, fmt.Errorf("item `%s` not found on host with id %d", hostAvailabilityItemKey, hostID)
+	}
+
+	h, err := iObjects[0].ValueType.HistoryTable()
+	if err != nil {
+		return nil, err
+	}
+
+	hObjects, _, err := z.HistoryGet(HistoryGetParams{
+		History:   h,
+		ItemIDs:   []int{iObjects[0].ItemID},
+		TimeFrom:  int(from.Unix()),
+		TimeTill:  int(to.Unix()),
+		Sortfield: "clock",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch r := hObjects.(type) {
+	case *[]HistoryIntegerObject:
+		return *r, nil
+	case *[]HistoryFloatObject:
+		var result []HistoryIntegerObject
+		for _, o := range *r {
+			result = append(result, HistoryIntegerObject{
+				Clock:  o.Clock,
+				ItemID: o.ItemID,
+				NS:     o.NS,
+				Value:  int(o.Value),
+			})
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("item `%s` on host with id %d has non-numeric value type %d", hostAvailabilityItemKey, hostID, iObjects[0].ValueType)
+}
+
+// AssignHostProxy sets the proxy monitoring the host. Zero `proxyID` means the host is monitored
+// by Zabbix server. Proxy is validated before host update: it must exist and, if it is a passive one,
+// it must have an interface Zabbix server is able to connect to
+func (z *Context) AssignHostProxy(hostID, proxyID int) error {
+
+	if proxyID != 0 {
+
+		pObjects, _, err := z.ProxyGet(ProxyGetParams{
+			ProxyIDs:        []int{proxyID},
+			SelectInterface: SelectExtendedOutput,
+			GetParameters: GetParameters{
+				Output: SelectFields{"proxyid", "host", "status"},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("get proxy error: %v", err)
+		}
+
+		if len(pObjects) == 0 {
+			return fmt.Errorf("proxy %d does not exist", proxyID)
+		}
+
+		p := pObjects[0]
+
+		switch p.Status {
+		case ProxyStatusActive:
+		case ProxyStatusPassive:
+			if p.Interface == nil || (p.Interface.IP == "" && p.Interface.DNS == "") {
+				return fmt.Errorf("passive proxy %s has no interface to connect to", p.Host)
+			}
+		default:
+			return fmt.Errorf("proxy %s has unknown status %d", p.Host, p.Status)
+		}
+	}
+
+	// Map is used to be able to set zero `proxy_hostid`
+	if _, err := z.request("host.update", map[string]interface{}{
+		"hostid":       hostID,
+		"proxy_hostid": proxyID,
+	}, &hostUpdateResult{}); err != nil {
+		return fmt.Errorf("update host error: %v", err)
+	}
+
+	return nil
+}
+
+// SetInventoryMode sets host inventory mode (see `HostInventoryMode*` consts) for the hosts with IDs `hostIDs`
+// within a single `host.update` call and returns IDs of the updated hosts
+func (z *Context) SetInventoryMode(hostIDs []int, mode int) ([]int, error) {
+
+	var result hostUpdateResult
+
+	switch mode {
+	case HostInventoryModeDisabled, HostInventoryModeManual, HostInventoryModeAutomatic:
+	default:
+		return nil, fmt.Errorf("invalid host inventory mode %d, must be -1, 0 or 1", mode)
+	}
+
+	var params []map[string]interface{}
+
+	// Maps are used instead of `HostObject` to send zero (manual) mode
+	for _, id := range hostIDs {
+		params = append(params, map[string]interface{}{
+			"hostid":         id,
+			"inventory_mode": mode,
+		})
+	}
+
+	if _, err := z.request("host.update", params, &result); err != nil {
+		return nil, fmt.Errorf("host inventory mode update error: %v", err)
+	}
+
+	return result.HostIDs, nil
+}
+
+// CreateHostsFromTemplate creates hosts with specified names within a single `host.create` call.
+// All hosts get the same groups, linked templates and an interface derived from `ifaceTemplate`:
+//   - if `ifaceTemplate.UseIP` is `HostinterfaceUseipIP`, the first host gets `ifaceTemplate.IP`
+//     and each next host gets the next IPv4 address (e.g. `10.0.0.10`, `10.0.0.11`, ...)
+//   - if `ifaceTemplate.UseIP` is `HostinterfaceUseipDNS`, the host name is used as DNS name. If
+//     `ifaceTemplate.DNS` is set, it is treated as domain and appended to the host name
+//     (e.g. `web01.example.com` for `example.com`)
+//
+// Other interface fields (type, port, details) are copied as is and interface is set as main one
+func (z *Context) CreateHostsFromTemplate(names []string, groupIDs, templateIDs []int, ifaceTemplate HostinterfaceObject) ([]int, error) {
+
+	var (
+		hosts  []HostObject
+		groups []HostgroupObject
+		tpls   []TemplateObject
+		ip     uint32
+	)
+
+	if len(names) == 0 {
+		return nil, errors.New("no host names specified")
+	}
+
+	if ifaceTemplate.UseIP == HostinterfaceUseipIP {
+		ip4 := net.ParseIP(ifaceTemplate.IP).To4()
+		if ip4 == nil {
+			return nil, fmt.Errorf("interface template has wrong IPv4 address `%s`", ifaceTemplate.IP)
+		}
+		ip = binary.BigEndian.Uint32(ip4)
+		if uint64(ip)+uint64(len(names)-1) > 0xffffffff {
+			return nil, errors.New("not enough IPv4 addresses for all hosts")
+		}
+	}
+
+	for _, g := range groupIDs {
+		groups = append(groups, HostgroupObject{
+			GroupID: g,
+		})
+	}
+
+	for _, t := range templateIDs {
+		tpls = append(tpls, TemplateObject{
+			TemplateID: t,
+		})
+	}
+
+	for n, name := range names {
+
+		iface := HostinterfaceObject{
+			Main:    HostinterfaceMainDefault,
+			Port:    ifaceTemplate.Port,
+			Type:    ifaceTemplate.Type,
+			UseIP:   ifaceTemplate.UseIP,
+			Details: ifaceTemplate.Details,
+		}
+
+		if ifaceTemplate.UseIP == HostinterfaceUseipIP {
+			b := make(net.IP, net.IPv4len)
+			binary.BigEndian.PutUint32(b, ip+uint32(n))
+			iface.IP = b.String()
+		} else {
+			iface.DNS = name
+			if ifaceTemplate.DNS != "" {
+				iface.DNS = name + "." + ifaceTemplate.DNS
+			}
+		}
+
+		hosts = append(hosts, HostObject{
+			Host:       name,
+			Groups:     groups,
+			Templates:  tpls,
+			Interfaces: []HostinterfaceObject{iface},
+		})
+	}
+
+	hCreatedIDs, _, err := z.HostCreate(hosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return hCreatedIDs, nil
+}
+
+// PurgeTemplateFromHost unlinks the template from the host and clears (removes) all entities
+// inherited from it. After that host items are checked and an error listing the items left
+// inherited from the template is returned if cleanup is incomplete
+func (z *Context) PurgeTemplateFromHost(hostID, templateID int) error {
+
+	tItems, _, err := z.ItemGet(ItemGetParams{
+		TemplateIDs: []int{templateID},
+		GetParameters: GetParameters{
+			Output: SelectFields{"itemid"},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("get template items error: %v", err)
+	}
+
+	if _, _, err := z.HostUpdate([]HostObject{
+		{
+			HostID: hostID,
+			TemplatesClear: []TemplateObject{
+				{
+					TemplateID: templateID,
+				},
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("unlink template error: %v", err)
+	}
+
+	if len(tItems) == 0 {
+		return nil
+	}
+
+	var tItemIDs []int
+	for _, i := range tItems {
+		tItemIDs = append(tItemIDs, i.ItemID)
+	}
+
+	hItems, _, err := z.ItemGet(ItemGetParams{
+		HostIDs: []int{hostID},
+		GetParameters: GetParameters{
+			Output: SelectFields{"itemid", "key_", "templateid"},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("get host items error: %v", err)
+	}
+
+	var left []string
+
+	for _, i := range hItems {
+		if containsInt(tItemIDs, i.TemplateID) == true {
+			left = append(left, fmt.Sprintf("%s (id: %d)", i.Key, i.ItemID))
+		}
+	}
+
+	if len(left) > 0 {
+		return fmt.Errorf("template %d purge from host %d is incomplete, items left: %s", templateID, hostID, strings.Join(left, ", "))
+	}
+
+	return nil
+}
+
+// TeardownHosts deletes the hosts and returns IDs of deleted ones. Host entities (e.g. items, triggers,
+// graphs) are deleted by Zabbix along with the host. If `deleteEmptyGroups` is set, host groups of
+// the deleted hosts left without any hosts and templates are deleted as well (internal groups are kept)
+func (z *Context) TeardownHosts(hostIDs []int, deleteEmptyGroups bool) ([]int, error) {
+
+	var groupIDs []int
+
+	if len(hostIDs) == 0 {
+		return []int{}, nil
+	}
+
+	if deleteEmptyGroups == true {
+
+		hObjects, _, err := z.HostGet(HostGetParams{
+			HostIDs:      hostIDs,
+			SelectGroups: SelectFields{"groupid"},
+			GetParameters: GetParameters{
+				Output: SelectFields{"hostid"},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get hosts error: %v", err)
+		}
+
+		for _, h := range hObjects {
+			for _, g := range h.Groups {
+				if containsInt(groupIDs, g.GroupID) == false {
+					groupIDs = append(groupIDs, g.GroupID)
+				}
+			}
+		}
+	}
+
+	hDeletedIDs, _, err := z.HostDelete(hostIDs)
+	if err != nil {
+		return nil, fmt.Errorf("delete hosts error: %v", err)
+	}
+
+	if len(groupIDs) == 0 {
+		return hDeletedIDs, nil
+	}
+
+	gObjects, _, err := z.HostgroupGet(HostgroupGetParams{
+		GroupIDs:        groupIDs,
+		SelectHosts:     SelectFields{"hostid"},
+		SelectTemplates: SelectFields{"templateid"},
+		GetParameters: GetParameters{
+			Output: SelectFields{"groupid", "internal"},
+		},
+	})
+	if err != nil {
+		return hDeletedIDs, fmt.Errorf("get host groups error: %v", err)
+	}
+
+	var emptyIDs []int
+
+	for _, g := range gObjects {
+		if g.Internal == HostgroupInternalFalse && len(g.Hosts) == 0 && len(g.Templates) == 0 {
+			emptyIDs = append(emptyIDs, g.GroupID)
+		}
+	}
+
+	if len(emptyIDs) > 0 {
+		if _, _, err := z.HostgroupDelete(emptyIDs); err != nil {
+			return hDeletedIDs, fmt.Errorf("delete empty host groups error: %v", err)
+		}
+	}
+
+	return hDeletedIDs, nil
+}
+
+// GetHostsInGroupWithTemplate returns hosts of the group with `groupID` directly linked to the template
+// with `templateID`. Hosts linked to templates which only link the template (nested templates) are not
+// returned, since `templateids` filter considers direct links only. Zabbix API intersects `groupids`
+// and `templateids` filters, so a single `host.get` request is sent
+func (z *Context) GetHostsInGroupWithTemplate(groupID, templateID int) ([]HostObject, error) {
+
+	hObjects, _, err := z.HostGet(HostGetParams{
+		GroupIDs:    []int{groupID},
+		TemplateIDs: []int{templateID},
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hObjects, nil
+}
+
+// GetPrototypeDiscoveredHosts returns hosts discovered from the host prototype. Zabbix API does not
+// allow to filter hosts by host prototype, so discovered hosts are requested and filtered by prototype
+// linkage (see `HostDiscoveryObject`). Discovered hosts inherit proxy (`ProxyHostID`) of the parent host
+func (z *Context) GetPrototypeDiscoveredHosts(hostPrototypeID int) ([]HostObject, error) {
+
+	hObjects, _, err := z.HostGet(HostGetParams{
+		SelectHostDiscovery: SelectExtendedOutput,
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+			Filter: map[string]interface{}{
+				"flags": HostFlagsDiscovered,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := []HostObject{}
+
+	for _, h := range hObjects {
+		if h.HostDiscovery != nil && h.HostDiscovery.ParentHostID == hostPrototypeID {
+			r = append(r, h)
+		}
+	}
+
+	return r, nil
+}
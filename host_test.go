@@ -1,16 +1,22 @@
 package zabbix
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
+	"strconv"
 	"testing"
+	"time"
 )
 
 const (
-	testHostName   = "testHost"
-	testHostIP     = "10.1.1.1"
-	testHostPort   = "10150"
-	testMacro      = "{$TEST_MACRO}"
-	testMacroValue = "testMacroValue"
+	testHostName    = "testHost"
+	testHostIP      = "10.1.1.1"
+	testHostPort    = "10150"
+	testMacro       = "{$TEST_MACRO}"
+	testMacroValue  = "testMacroValue"
+	testHostClone   = "testHostClone"
+	testHostCloneIP = "10.1.1.2"
 )
 
 func TestHostCRUD(t *testing.T) {
@@ -37,6 +43,9 @@ func TestHostCRUD(t *testing.T) {
 
 	// Get
 	testHostGet(t, z, hCreatedIDs, tCreatedIDs, hgCreatedIDs)
+
+	// Clone
+	testHostCloneHost(t, z, hCreatedIDs[0], tCreatedIDs)
 }
 
 func testHostCreate(t *testing.T, z Context, hgCreatedIDs, tCreatedIDs []int) []int {
@@ -190,3 +199,652 @@ func testHostGet(t *testing.T, z Context, hCreatedIDs, tCreatedIDs, hgCreatedIDs
 
 	return hObjects
 }
+
+func testHostCloneHost(t *testing.T, z Context, hCreatedID int, tCreatedIDs []int) {
+
+	// Zero values must be copied as well (Zabbix defaults are -1)
+	if _, err := z.SetInventoryMode([]int{hCreatedID}, HostInventoryModeManual); err != nil {
+		t.Fatal("Host clone error:", err)
+	}
+
+	var result hostUpdateResult
+
+	if _, err := z.request("host.update", map[string]interface{}{
+		"hostid":        hCreatedID,
+		"ipmi_authtype": HostIpmiAuthtypeNone,
+	}, &result); err != nil {
+		t.Fatal("Host clone error:", err)
+	}
+
+	hClonedID, err := z.CloneHost(hCreatedID, testHostClone, testHostCloneIP)
+	if err != nil {
+		t.Fatal("Host clone error:", err)
+	}
+	defer testHostDelete(t, z, []int{hClonedID})
+
+	hObjects, _, err := z.HostGet(HostGetParams{
+		HostIDs:               []int{hClonedID},
+		SelectInterfaces:      SelectExtendedOutput,
+		SelectParentTemplates: SelectExtendedOutput,
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		t.Fatal("Host clone error:", err)
+	}
+
+	if len(hObjects) == 0 {
+		t.Fatal("Host clone error: unable to find cloned host")
+	}
+
+	h := hObjects[0]
+
+	if h.Host != testHostClone {
+		t.Error("Host clone error: cloned host name mismatch")
+	}
+
+	if len(h.Interfaces) == 0 || h.Interfaces[0].IP != testHostCloneIP {
+		t.Error("Host clone error: cloned host interface IP mismatch")
+	}
+
+	if h.InventoryMode != HostInventoryModeManual || h.IpmiAuthtype != HostIpmiAuthtypeNone {
+		t.Errorf("Host clone error: unexpected inventory mode %d or IPMI authtype %d", h.InventoryMode, h.IpmiAuthtype)
+	}
+
+	var tClonedIDs []int
+	for _, e := range h.ParentTemplates {
+		tClonedIDs = append(tClonedIDs, e.TemplateID)
+	}
+
+	if reflect.DeepEqual(tClonedIDs, tCreatedIDs) == false {
+		t.Error("Host clone error: linked templates of source and cloned hosts are mismatch")
+	}
+
+	t.Logf("Host clone: success")
+}
+
+func TestHostCloneZeroFields(t *testing.T) {
+
+	var created []map[string]interface{}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "host.get":
+			return []map[string]interface{}{
+				{"hostid": "10084", "host": "source", "inventory_mode": "0", "ipmi_authtype": "0"},
+			}, nil
+		case "host.create":
+
+			if err := json.Unmarshal(params, &created); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"hostids": []string{"10085"}}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	hClonedID, err := z.CloneHost(10084, "clone", "10.0.0.2")
+	if err != nil {
+		t.Fatal("Host clone zero fields error:", err)
+	}
+
+	if hClonedID != 10085 {
+		t.Errorf("Host clone zero fields error: unexpected ID %d", hClonedID)
+	}
+
+	if len(created) != 1 || created[0]["host"] != "clone" ||
+		created[0]["inventory_mode"] != float64(HostInventoryModeManual) || created[0]["ipmi_authtype"] != float64(HostIpmiAuthtypeNone) {
+		t.Errorf("Host clone zero fields error: unexpected params %v", created)
+	}
+
+	t.Logf("Host clone zero fields: success")
+}
+
+func TestHostAvailabilityHistory(t *testing.T) {
+
+	itemExists := true
+	valueType := ItemValueTypeNumericUnsigned
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "item.get":
+
+			var p ItemGetParams
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			if p.Filter["key_"] != hostAvailabilityItemKey {
+				return nil, fmt.Errorf("unexpected filter %v", p.Filter)
+			}
+
+			if itemExists == false {
+				return []interface{}{}, nil
+			}
+
+			return []map[string]interface{}{
+				{"itemid": "900", "value_type": strconv.Itoa(int(valueType))},
+			}, nil
+
+		case "history.get":
+
+			var p HistoryGetParams
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			if reflect.DeepEqual(p.ItemIDs, []int{900}) == false || p.TimeFrom != 1000 || p.TimeTill != 2000 {
+				return nil, fmt.Errorf("unexpected params %s", string(params))
+			}
+
+			// History table must match the item value type
+			if h, _ := valueType.HistoryTable(); p.History != h {
+				return nil, fmt.Errorf("unexpected history table %d for value type %d", p.History, valueType)
+			}
+
+			if valueType == ItemValueTypeFloat {
+				return []map[string]interface{}{
+					{"itemid": "900", "clock": "1100", "value": "1.0000", "ns": "0"},
+					{"itemid": "900", "clock": "1200", "value": "2.0000", "ns": "0"},
+				}, nil
+			}
+
+			return []map[string]interface{}{
+				{"itemid": "900", "clock": "1100", "value": "1", "ns": "0"},
+				{"itemid": "900", "clock": "1200", "value": "2", "ns": "0"},
+			}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	hObjects, err := z.GetHostAvailabilityHistory(1, time.Unix(1000, 0), time.Unix(2000, 0))
+	if err != nil {
+		t.Fatal("Host availability history error:", err)
+	}
+
+	if len(hObjects) != 2 || hObjects[1].Value != HostAvailableUnavailable {
+		t.Errorf("Host availability history error: unexpected result %v", hObjects)
+	}
+
+	// Float internal item
+	valueType = ItemValueTypeFloat
+
+	hObjects, err = z.GetHostAvailabilityHistory(1, time.Unix(1000, 0), time.Unix(2000, 0))
+	if err != nil {
+		t.Fatal("Host availability history error:", err)
+	}
+
+	if len(hObjects) != 2 || hObjects[1].Value != HostAvailableUnavailable {
+		t.Errorf("Host availability history error: unexpected float result %v", hObjects)
+	}
+
+	// Non-numeric internal item
+	valueType = ItemValueTypeText
+
+	if _, err := z.GetHostAvailabilityHistory(1, time.Unix(1000, 0), time.Unix(2000, 0)); err == nil {
+		t.Error("Host availability history error: non-numeric item not detected")
+	}
+
+	// Internal item does not exist
+	itemExists = false
+
+	if _, err := z.GetHostAvailabilityHistory(1, time.Unix(1000, 0), time.Unix(2000, 0)); err == nil {
+		t.Error("Host availability history error: missing internal item not detected")
+	}
+
+	t.Logf("Host availability history: success")
+}
+
+func TestHostAssignProxy(t *testing.T) {
+
+	var updated map[string]interface{}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "proxy.get":
+
+			var p ProxyGetParams
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			proxies := map[int]map[string]interface{}{
+				10: {"proxyid": "10", "host": "activeProxy", "status": "5", "interface": []interface{}{}},
+				20: {"proxyid": "20", "host": "passiveProxy", "status": "6", "interface": map[string]interface{}{"interfaceid": "1", "ip": "10.1.1.5", "dns": "", "port": "10051", "useip": "1"}},
+			}
+
+			r := []map[string]interface{}{}
+			for _, id := range p.ProxyIDs {
+				if p, b := proxies[id]; b == true {
+					r = append(r, p)
+				}
+			}
+
+			return r, nil
+		case "host.update":
+			updated = nil
+			if err := json.Unmarshal(params, &updated); err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"hostids": []string{"10084"}}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	tests := []struct {
+		name    string
+		proxyID int
+		valid   bool
+	}{
+		{
+			name:    "active proxy",
+			proxyID: 10,
+			valid:   true,
+		},
+		{
+			name:    "passive proxy",
+			proxyID: 20,
+			valid:   true,
+		},
+		{
+			name:    "server",
+			proxyID: 0,
+			valid:   true,
+		},
+		{
+			name:    "nonexistent proxy",
+			proxyID: 99,
+			valid:   false,
+		},
+	}
+
+	for _, tt := range tests {
+
+		updated = nil
+
+		err := z.AssignHostProxy(10084, tt.proxyID)
+
+		if tt.valid == false {
+			if err == nil || updated != nil {
+				t.Errorf("Host assign proxy error (%s): expected validation error", tt.name)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Host assign proxy error (%s): %v", tt.name, err)
+			continue
+		}
+
+		if updated["proxy_hostid"] != float64(tt.proxyID) {
+			t.Errorf("Host assign proxy error (%s): unexpected update params %v", tt.name, updated)
+		}
+	}
+
+	t.Logf("Host assign proxy: success")
+}
+
+func TestHostSetInventoryMode(t *testing.T) {
+
+	var updated []map[string]interface{}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "host.update" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &updated); err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{"hostids": []string{"10084", "10085"}}, nil
+	})
+	defer closeMock()
+
+	hostIDs, err := z.SetInventoryMode([]int{10084, 10085}, HostInventoryModeAutomatic)
+	if err != nil {
+		t.Fatal("Host set inventory mode error:", err)
+	}
+
+	if reflect.DeepEqual(hostIDs, []int{10084, 10085}) == false {
+		t.Errorf("Host set inventory mode error: unexpected IDs %v", hostIDs)
+	}
+
+	expected := []map[string]interface{}{
+		{"hostid": float64(10084), "inventory_mode": float64(1)},
+		{"hostid": float64(10085), "inventory_mode": float64(1)},
+	}
+
+	if reflect.DeepEqual(updated, expected) == false {
+		t.Errorf("Host set inventory mode error: unexpected params %v", updated)
+	}
+
+	// Zero (manual) mode is sent
+	if _, err := z.SetInventoryMode([]int{10084}, HostInventoryModeManual); err != nil {
+		t.Fatal("Host set inventory mode error:", err)
+	}
+
+	if len(updated) != 1 || updated[0]["inventory_mode"] != float64(0) {
+		t.Errorf("Host set inventory mode error: unexpected params %v", updated)
+	}
+
+	updated = nil
+
+	if _, err := z.SetInventoryMode([]int{10084}, 2); err == nil || updated != nil {
+		t.Error("Host set inventory mode error: expected validation error")
+	}
+
+	t.Logf("Host set inventory mode: success")
+}
+
+func TestHostCreateFromTemplate(t *testing.T) {
+
+	var sent []HostObject
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "host.create" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		sent = nil
+		if err := json.Unmarshal(params, &sent); err != nil {
+			return nil, err
+		}
+
+		var ids []string
+		for i := range sent {
+			ids = append(ids, fmt.Sprintf("%d", 10100+i))
+		}
+
+		return map[string]interface{}{"hostids": ids}, nil
+	})
+	defer closeMock()
+
+	names := []string{"web01", "web02", "web03"}
+
+	hCreatedIDs, err := z.CreateHostsFromTemplate(names, []int{2}, []int{10001}, HostinterfaceObject{
+		IP:    "10.0.0.254",
+		Port:  "10050",
+		Type:  HostinterfaceTypeAgent,
+		UseIP: HostinterfaceUseipIP,
+	})
+	if err != nil {
+		t.Fatal("Host create from template error:", err)
+	}
+
+	if reflect.DeepEqual(hCreatedIDs, []int{10100, 10101, 10102}) == false {
+		t.Errorf("Host create from template error: unexpected IDs %v", hCreatedIDs)
+	}
+
+	if len(sent) != 3 {
+		t.Fatalf("Host create from template error: unexpected hosts %v", sent)
+	}
+
+	ips := []string{"10.0.0.254", "10.0.0.255", "10.0.1.0"}
+
+	for i, h := range sent {
+
+		if h.Host != names[i] ||
+			len(h.Groups) != 1 || h.Groups[0].GroupID != 2 ||
+			len(h.Templates) != 1 || h.Templates[0].TemplateID != 10001 {
+			t.Errorf("Host create from template error: unexpected host %v", h)
+		}
+
+		if len(h.Interfaces) != 1 || h.Interfaces[0].IP != ips[i] || h.Interfaces[0].Main != HostinterfaceMainDefault {
+			t.Errorf("Host create from template error: unexpected interfaces %v", h.Interfaces)
+		}
+	}
+
+	// DNS names
+	if _, err := z.CreateHostsFromTemplate(names, []int{2}, []int{10001}, HostinterfaceObject{
+		DNS:   "example.com",
+		Port:  "10050",
+		Type:  HostinterfaceTypeAgent,
+		UseIP: HostinterfaceUseipDNS,
+	}); err != nil {
+		t.Fatal("Host create from template error:", err)
+	}
+
+	for i, h := range sent {
+		if len(h.Interfaces) != 1 || h.Interfaces[0].DNS != names[i]+".example.com" {
+			t.Errorf("Host create from template error: unexpected interfaces %v", h.Interfaces)
+		}
+	}
+
+	t.Logf("Host create from template: success")
+}
+
+func TestHostPurgeTemplate(t *testing.T) {
+
+	var purged bool
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "item.get":
+
+			var p ItemGetParams
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			// Template items
+			if len(p.TemplateIDs) > 0 {
+				return []map[string]interface{}{{"itemid": "1001"}, {"itemid": "1002"}}, nil
+			}
+
+			// Host items
+			items := []map[string]interface{}{
+				{"itemid": "2003", "key_": testItemKey, "templateid": "0"},
+			}
+			if purged == false {
+				items = append(items,
+					map[string]interface{}{"itemid": "2001", "key_": "agent.ping", "templateid": "1001"},
+					map[string]interface{}{"itemid": "2002", "key_": "system.uptime", "templateid": "1002"},
+				)
+			}
+
+			return items, nil
+		case "host.update":
+
+			var p []map[string]interface{}
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			b, _ := json.Marshal(p)
+			if string(b) != `[{"hostid":10084,"templates_clear":[{"templateid":10001}]}]` {
+				return nil, fmt.Errorf("unexpected params %s", string(b))
+			}
+
+			return map[string]interface{}{"hostids": []string{"10084"}}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	// Incomplete cleanup
+	if err := z.PurgeTemplateFromHost(10084, 10001); err == nil {
+		t.Error("Host purge template error: expected incomplete cleanup error")
+	}
+
+	// Templated items are gone
+	purged = true
+
+	if err := z.PurgeTemplateFromHost(10084, 10001); err != nil {
+		t.Error("Host purge template error:", err)
+	}
+
+	t.Logf("Host purge template: success")
+}
+
+func TestHostTeardown(t *testing.T) {
+
+	var groupsDeleted []int
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "host.get":
+
+			return []map[string]interface{}{
+				{"hostid": "10084", "groups": []map[string]interface{}{{"groupid": "2"}, {"groupid": "5"}}},
+				{"hostid": "10085", "groups": []map[string]interface{}{{"groupid": "5"}, {"groupid": "6"}}},
+			}, nil
+		case "host.delete":
+
+			var p []int
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"hostids": p}, nil
+		case "hostgroup.get":
+
+			return []map[string]interface{}{
+				{"groupid": "2", "internal": "0", "hosts": []interface{}{}, "templates": []map[string]interface{}{{"templateid": "10001"}}},
+				{"groupid": "5", "internal": "0", "hosts": []interface{}{}, "templates": []interface{}{}},
+				{"groupid": "6", "internal": "0", "hosts": []map[string]interface{}{{"hostid": "10086"}}, "templates": []interface{}{}},
+			}, nil
+		case "hostgroup.delete":
+
+			if err := json.Unmarshal(params, &groupsDeleted); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"groupids": groupsDeleted}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	// Without groups cleanup
+	hDeletedIDs, err := z.TeardownHosts([]int{10084, 10085}, false)
+	if err != nil {
+		t.Fatal("Host teardown error:", err)
+	}
+
+	if reflect.DeepEqual(hDeletedIDs, []int{10084, 10085}) == false {
+		t.Errorf("Host teardown error: unexpected deleted IDs %v", hDeletedIDs)
+	}
+
+	if groupsDeleted != nil {
+		t.Errorf("Host teardown error: unexpected host groups deletion %v", groupsDeleted)
+	}
+
+	// With groups cleanup
+	hDeletedIDs, err = z.TeardownHosts([]int{10084, 10085}, true)
+	if err != nil {
+		t.Fatal("Host teardown error:", err)
+	}
+
+	if reflect.DeepEqual(hDeletedIDs, []int{10084, 10085}) == false {
+		t.Errorf("Host teardown error: unexpected deleted IDs %v", hDeletedIDs)
+	}
+
+	if reflect.DeepEqual(groupsDeleted, []int{5}) == false {
+		t.Errorf("Host teardown error: unexpected deleted host groups %v", groupsDeleted)
+	}
+
+	t.Logf("Host teardown: success")
+}
+
+func TestHostInGroupWithTemplate(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "host.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		var p HostGetParams
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		// Both filters must be sent in a single request
+		if reflect.DeepEqual(p.GroupIDs, []int{2}) == false || reflect.DeepEqual(p.TemplateIDs, []int{10001}) == false {
+			return nil, fmt.Errorf("unexpected params %s", params)
+		}
+
+		// Intersection is calculated by Zabbix
+		return []map[string]interface{}{
+			{"hostid": "10084"},
+			{"hostid": "10087"},
+		}, nil
+	})
+	defer closeMock()
+
+	hObjects, err := z.GetHostsInGroupWithTemplate(2, 10001)
+	if err != nil {
+		t.Fatal("Host in group with template error:", err)
+	}
+
+	var ids []int
+	for _, h := range hObjects {
+		ids = append(ids, h.HostID)
+	}
+
+	if reflect.DeepEqual(ids, []int{10084, 10087}) == false {
+		t.Errorf("Host in group with template error: unexpected hosts %v", ids)
+	}
+
+	t.Logf("Host in group with template: success")
+}
+
+func TestHostPrototypeDiscoveredHosts(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "host.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return []map[string]interface{}{
+			{"hostid": "10101", "host": "vm-1", "flags": "4", "proxy_hostid": "10050", "hostDiscovery": map[string]interface{}{"parent_hostid": "10090", "host": "{#VM.NAME}"}},
+			{"hostid": "10102", "host": "vm-2", "flags": "4", "proxy_hostid": "10050", "hostDiscovery": map[string]interface{}{"parent_hostid": "10090", "host": "{#VM.NAME}"}},
+			{"hostid": "10103", "host": "db-1", "flags": "4", "proxy_hostid": "0", "hostDiscovery": map[string]interface{}{"parent_hostid": "10091", "host": "{#DB.NAME}"}},
+			{"hostid": "10104", "host": "broken", "flags": "4", "hostDiscovery": []interface{}{}},
+		}, nil
+	})
+	defer closeMock()
+
+	hObjects, err := z.GetPrototypeDiscoveredHosts(10090)
+	if err != nil {
+		t.Fatal("Host prototype discovered hosts error:", err)
+	}
+
+	var names []string
+	for _, h := range hObjects {
+		if h.ProxyHostID != 10050 {
+			t.Errorf("Host prototype discovered hosts error: unexpected proxy for host %s", h.Host)
+		}
+		names = append(names, h.Host)
+	}
+
+	if reflect.DeepEqual(names, []string{"vm-1", "vm-2"}) == false {
+		t.Errorf("Host prototype discovered hosts error: unexpected hosts %v", names)
+	}
+
+	t.Logf("Host prototype discovered hosts: success")
+}
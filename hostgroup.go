@@ -1,5 +1,7 @@
 package zabbix
 
+import "strings"
+
 // For `HostgroupObject` field: `Status`
 const (
 	HostgroupFlagsPlain       = 0
@@ -12,6 +14,9 @@ const (
 	HostgroupInternalTrue  = 1
 )
 
+// Separator of nested host group names, e.g. `Linux servers/Web/Frontend`
+const hostgroupPathSeparator = "/"
+
 // HostgroupObject struct is used to store hostgroup operations results
 //
 // see: https://www.zabbix.com/documentation/5.0/manual/api/reference/hostgroup/object
@@ -109,3 +114,47 @@ func (z *Context) HostgroupDelete(groupIDs []int) ([]int, int, error) {
 
 	return result.GroupIDs, status, nil
 }
+
+// SplitGroupPath splits nested host group name into levels, e.g. `A/B/C` into `[A B C]`.
+// Empty levels (e.g. due to leading or trailing separators) are skipped
+func SplitGroupPath(name string) []string {
+
+	r := []string{}
+
+	for _, l := range strings.Split(name, hostgroupPathSeparator) {
+		if l != "" {
+			r = append(r, l)
+		}
+	}
+
+	return r
+}
+
+// GroupDepth returns nesting level of the host group name, e.g. `1` for `A` and `3` for `A/B/C`
+func GroupDepth(name string) int {
+	return len(SplitGroupPath(name))
+}
+
+// GetTopLevelGroups returns host groups which names contain no nesting separator.
+// Zabbix API is not able to filter groups this way, so all groups are requested
+func (z *Context) GetTopLevelGroups() ([]HostgroupObject, error) {
+
+	hgObjects, _, err := z.HostgroupGet(HostgroupGetParams{
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := []HostgroupObject{}
+
+	for _, g := range hgObjects {
+		if strings.Contains(g.Name, hostgroupPathSeparator) == false {
+			r = append(r, g)
+		}
+	}
+
+	return r, nil
+}
@@ -1,6 +1,8 @@
 package zabbix
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 )
@@ -25,6 +27,67 @@ func TestHostgroupCRUD(t *testing.T) {
 	testHostgroupGet(t, z, hgCreatedIDs)
 }
 
+func TestHostgroupPath(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		path  []string
+		depth int
+	}{
+		{name: "A/B/C", path: []string{"A", "B", "C"}, depth: 3},
+		{name: "Linux servers", path: []string{"Linux servers"}, depth: 1},
+		{name: "/A//B/", path: []string{"A", "B"}, depth: 2},
+		{name: "", path: []string{}, depth: 0},
+	}
+
+	for _, tt := range tests {
+
+		if p := SplitGroupPath(tt.name); reflect.DeepEqual(p, tt.path) == false {
+			t.Errorf("Hostgroup path error (%s): unexpected path %q", tt.name, p)
+		}
+
+		if d := GroupDepth(tt.name); d != tt.depth {
+			t.Errorf("Hostgroup path error (%s): unexpected depth %d", tt.name, d)
+		}
+	}
+
+	t.Logf("Hostgroup path: success")
+}
+
+func TestHostgroupTopLevel(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "hostgroup.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return []map[string]interface{}{
+			{"groupid": "1", "name": "A"},
+			{"groupid": "2", "name": "A/B"},
+			{"groupid": "3", "name": "A/B/C"},
+			{"groupid": "4", "name": "Linux servers"},
+		}, nil
+	})
+	defer closeMock()
+
+	hgObjects, err := z.GetTopLevelGroups()
+	if err != nil {
+		t.Fatal("Hostgroup top level error:", err)
+	}
+
+	var ids []int
+	for _, g := range hgObjects {
+		ids = append(ids, g.GroupID)
+	}
+
+	if reflect.DeepEqual(ids, []int{1, 4}) == false {
+		t.Errorf("Hostgroup top level error: unexpected groups %v", ids)
+	}
+
+	t.Logf("Hostgroup top level: success")
+}
+
 func testHostgroupCreate(t *testing.T, z Context) []int {
 
 	hgCreatedIDs, _, err := z.HostgroupCreate([]HostgroupObject{
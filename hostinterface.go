@@ -1,5 +1,10 @@
 package zabbix
 
+import (
+	"errors"
+	"fmt"
+)
+
 // For `HostinterfaceObject` field: `Main`
 const (
 	HostinterfaceMainNotDefault = 0
@@ -20,6 +25,13 @@ const (
 	HostinterfaceUseipIP  = 1
 )
 
+// For `HostinterfaceObject` field: `Available` (since Zabbix 6.0)
+const (
+	HostinterfaceAvailableUnknown     = 0
+	HostinterfaceAvailableAvailable   = 1
+	HostinterfaceAvailableUnavailable = 2
+)
+
 // For `HostinterfaceDetailsTagObject` field: `Bulk`
 const (
 	HostinterfaceDetailsTagBulkDontUse = 0
@@ -52,6 +64,14 @@ const (
 	HostinterfaceDetailsTagPrivProtocolAES = 1
 )
 
+// Zabbix API version availability is moved from hosts to interfaces in
+const hostinterfaceAvailabilityVersion = "6.0"
+
+// ErrHostinterfaceNotFound is returned by `ResolveInterfaceID()` if host has no main interface
+// of the requested type. Note that items not requiring an interface (e.g. `Zabbix agent (active)`)
+// must be created with zero `interfaceid`
+var ErrHostinterfaceNotFound = errors.New("host interface not found")
+
 // HostinterfaceObject struct is used to store hostinterface operations results
 //
 // see: https://www.zabbix.com/documentation/5.0/manual/api/reference/hostinterface/object#hostinterface
@@ -66,6 +86,12 @@ type HostinterfaceObject struct {
 	UseIP       int                             `json:"useip"` // has defined consts, see above
 	Details     []HostinterfaceDetailsTagObject `json:"details,omitempty"`
 
+	// Availability fields are read-only and available since Zabbix 6.0
+	Available    int    `json:"available,omitempty"` // has defined consts, see above
+	Error        string `json:"error,omitempty"`
+	ErrorsFrom   int    `json:"errors_from,omitempty"`
+	DisableUntil int    `json:"disable_until,omitempty"`
+
 	// Items []ItemObject `json:"items,omitempty"` // not implemented yet
 	Hosts []HostObject `json:"hosts,omitempty"`
 }
@@ -149,3 +175,145 @@ func (z *Context) HostinterfaceDelete(hostinterfaceIDs []int) ([]int, int, error
 
 	return result.InterfaceIDs, status, nil
 }
+
+// ResolveInterfaceID returns ID of the main (default) interface of the host with specified type
+// (see `HostinterfaceType*` consts). If host has no such interface, error wrapping
+// `ErrHostinterfaceNotFound` is returned
+func (z *Context) ResolveInterfaceID(hostID int, ifaceType int) (int, error) {
+
+	hiObjects, _, err := z.HostinterfaceGet(HostinterfaceGetParams{
+		HostIDs: []int{hostID},
+		GetParameters: GetParameters{
+			Output: SelectFields{"interfaceid", "main", "type"},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, hi := range hiObjects {
+		if hi.Type == ifaceType && hi.Main == HostinterfaceMainDefault {
+			return hi.InterfaceID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: host %d has no main interface of type %d", ErrHostinterfaceNotFound, hostID, ifaceType)
+}
+
+// TestInterfaceReachability checks Zabbix is able to reach the main interface of the host with specified type
+// (see `HostinterfaceType*` consts) and returns the last availability error if it is not reachable. Availability
+// is read from the interface since Zabbix 6.0 and from the host (per interface type) before. Note that newly
+// created interfaces are not reachable until Zabbix checks them (availability is unknown).
+// If host has no such interface, error wrapping `ErrHostinterfaceNotFound` is returned
+func (z *Context) TestInterfaceReachability(hostID int, ifaceType int) (bool, string, error) {
+
+	ifaceAvailability, err := z.apiVersionAtLeast(hostinterfaceAvailabilityVersion)
+	if err != nil {
+		return false, "", err
+	}
+
+	output := SelectFields{"interfaceid", "main", "type"}
+	if ifaceAvailability == true {
+		output = append(output, "available", "error")
+	}
+
+	hiObjects, _, err := z.HostinterfaceGet(HostinterfaceGetParams{
+		HostIDs: []int{hostID},
+		GetParameters: GetParameters{
+			Output: output,
+		},
+	})
+	if err != nil {
+		return false, "", fmt.Errorf("get host interfaces error: %v", err)
+	}
+
+	var iface *HostinterfaceObject
+
+	for i, hi := range hiObjects {
+		if hi.Type == ifaceType && hi.Main == HostinterfaceMainDefault {
+			iface = &hiObjects[i]
+			break
+		}
+	}
+
+	if iface == nil {
+		return false, "", fmt.Errorf("%w: host %d has no main interface of type %d", ErrHostinterfaceNotFound, hostID, ifaceType)
+	}
+
+	if ifaceAvailability == true {
+		return iface.Available == HostinterfaceAvailableAvailable, iface.Error, nil
+	}
+
+	hObjects, _, err := z.HostGet(HostGetParams{
+		HostIDs: []int{hostID},
+		GetParameters: GetParameters{
+			Output: SelectFields{"hostid", "available", "error", "snmp_available", "snmp_error",
+				"ipmi_available", "ipmi_error", "jmx_available", "jmx_error"},
+		},
+	})
+	if err != nil {
+		return false, "", fmt.Errorf("get host error: %v", err)
+	}
+
+	if len(hObjects) == 0 {
+		return false, "", fmt.Errorf("host with id %d not found", hostID)
+	}
+
+	h := hObjects[0]
+
+	switch ifaceType {
+	case HostinterfaceTypeAgent:
+		return h.Available == HostAvailableAvailable, h.Error, nil
+	case HostinterfaceTypeSNMP:
+		return h.SnmpAvailable == HostSnmpAvailableAvailable, h.SnmpError, nil
+	case HostinterfaceTypeIPMI:
+		return h.IpmiAvailable == HostIpmiAvailableAvailable, h.IpmiError, nil
+	case HostinterfaceTypeJMX:
+		return h.JmxAvailable == HostJmxAvailableAvailable, h.JmxError, nil
+	}
+
+	return false, "", fmt.Errorf("unknown host interface type %d", ifaceType)
+}
+
+// GetUnavailableInterfaces returns unavailable interfaces of all hosts in the host group.
+// Interface level availability is available since Zabbix 6.0 only
+func (z *Context) GetUnavailableInterfaces(groupID int) ([]HostinterfaceObject, error) {
+
+	if err := z.apiVersionRequire("6.0", "interface availability"); err != nil {
+		return nil, err
+	}
+
+	hObjects, _, err := z.HostGet(HostGetParams{
+		GroupIDs: []int{groupID},
+		GetParameters: GetParameters{
+			Output: SelectFields{"hostid"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get hosts error: %v", err)
+	}
+
+	if len(hObjects) == 0 {
+		return []HostinterfaceObject{}, nil
+	}
+
+	var hostIDs []int
+	for _, h := range hObjects {
+		hostIDs = append(hostIDs, h.HostID)
+	}
+
+	hiObjects, _, err := z.HostinterfaceGet(HostinterfaceGetParams{
+		HostIDs: hostIDs,
+		GetParameters: GetParameters{
+			Filter: map[string]interface{}{
+				"available": HostinterfaceAvailableUnavailable,
+			},
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get host interfaces error: %v", err)
+	}
+
+	return hiObjects, nil
+}
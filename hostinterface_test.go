@@ -1,6 +1,9 @@
 package zabbix
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 )
@@ -107,3 +110,181 @@ func testHostinterfaceGet(t *testing.T, z Context, hCreatedIDs []int) []Hostinte
 
 	return hiObjects
 }
+
+func TestHostinterfaceResolveInterfaceID(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "hostinterface.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return []map[string]interface{}{
+			{"interfaceid": "1", "main": "1", "type": "1"},
+			{"interfaceid": "2", "main": "0", "type": "2"},
+			{"interfaceid": "3", "main": "1", "type": "2"},
+		}, nil
+	})
+	defer closeMock()
+
+	tests := []struct {
+		name      string
+		ifaceType int
+		expected  int
+	}{
+		{
+			name:      "agent",
+			ifaceType: HostinterfaceTypeAgent,
+			expected:  1,
+		},
+		{
+			name:      "snmp",
+			ifaceType: HostinterfaceTypeSNMP,
+			expected:  3,
+		},
+	}
+
+	for _, tt := range tests {
+
+		id, err := z.ResolveInterfaceID(10084, tt.ifaceType)
+		if err != nil {
+			t.Fatalf("Hostinterface resolve interface id error (%s): %v", tt.name, err)
+		}
+
+		if id != tt.expected {
+			t.Errorf("Hostinterface resolve interface id error (%s): unexpected interface id %d", tt.name, id)
+		}
+	}
+
+	// Missing interface
+	if _, err := z.ResolveInterfaceID(10084, HostinterfaceTypeJMX); errors.Is(err, ErrHostinterfaceNotFound) == false {
+		t.Errorf("Hostinterface resolve interface id error (missing): expected not found error, got %v", err)
+	}
+
+	t.Logf("Hostinterface resolve interface id: success")
+}
+
+func TestHostinterfaceUnavailable(t *testing.T) {
+
+	version := "6.0.0"
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "apiinfo.version":
+			return version, nil
+		case "host.get":
+			return []map[string]interface{}{{"hostid": "10084"}, {"hostid": "10085"}}, nil
+		case "hostinterface.get":
+
+			var p HostinterfaceGetParams
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			if reflect.DeepEqual(p.HostIDs, []int{10084, 10085}) == false || p.Filter["available"] != float64(HostinterfaceAvailableUnavailable) {
+				return nil, fmt.Errorf("unexpected params %s", string(params))
+			}
+
+			return []map[string]interface{}{
+				{
+					"interfaceid":   "7",
+					"hostid":        "10085",
+					"type":          "2",
+					"main":          "1",
+					"ip":            "10.1.1.7",
+					"dns":           "",
+					"port":          "161",
+					"useip":         "1",
+					"available":     "2",
+					"error":         "Timeout while connecting to \"10.1.1.7:161\".",
+					"errors_from":   "1640000000",
+					"disable_until": "1640000300",
+				},
+			}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	hiObjects, err := z.GetUnavailableInterfaces(2)
+	if err != nil {
+		t.Fatal("Hostinterface unavailable error:", err)
+	}
+
+	if len(hiObjects) != 1 ||
+		hiObjects[0].Type != HostinterfaceTypeSNMP ||
+		hiObjects[0].Available != HostinterfaceAvailableUnavailable ||
+		hiObjects[0].Error != "Timeout while connecting to \"10.1.1.7:161\"." {
+		t.Errorf("Hostinterface unavailable error: unexpected result %v", hiObjects)
+	}
+
+	// Older version
+	z.apiVersion = ""
+	version = "5.0.2"
+
+	if _, err := z.GetUnavailableInterfaces(2); err == nil {
+		t.Error("Hostinterface unavailable error: expected version error")
+	}
+
+	t.Logf("Hostinterface unavailable: success")
+}
+
+func TestHostinterfaceReachability(t *testing.T) {
+
+	for _, version := range []string{"5.0.2", "6.0.0"} {
+
+		z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+			switch method {
+			case "apiinfo.version":
+				return version, nil
+			case "hostinterface.get":
+				return []map[string]interface{}{
+					{"interfaceid": "1", "main": "1", "type": "1", "available": "1", "error": ""},
+					{"interfaceid": "2", "main": "1", "type": "2", "available": "2", "error": "Timeout while connecting to \"10.1.1.1:161\"."},
+				}, nil
+			case "host.get":
+				return []map[string]interface{}{
+					{
+						"hostid":         "10084",
+						"available":      "1",
+						"error":          "",
+						"snmp_available": "2",
+						"snmp_error":     "Timeout while connecting to \"10.1.1.1:161\".",
+					},
+				}, nil
+			}
+
+			return nil, fmt.Errorf("unexpected method %s", method)
+		})
+
+		reachable, e, err := z.TestInterfaceReachability(10084, HostinterfaceTypeAgent)
+		if err != nil {
+			t.Fatalf("Hostinterface reachability error (%s): %v", version, err)
+		}
+
+		if reachable == false || e != "" {
+			t.Errorf("Hostinterface reachability error (%s): unexpected agent result %v, %s", version, reachable, e)
+		}
+
+		reachable, e, err = z.TestInterfaceReachability(10084, HostinterfaceTypeSNMP)
+		if err != nil {
+			t.Fatalf("Hostinterface reachability error (%s): %v", version, err)
+		}
+
+		if reachable == true || e != "Timeout while connecting to \"10.1.1.1:161\"." {
+			t.Errorf("Hostinterface reachability error (%s): unexpected SNMP result %v, %s", version, reachable, e)
+		}
+
+		if _, _, err := z.TestInterfaceReachability(10084, HostinterfaceTypeJMX); errors.Is(err, ErrHostinterfaceNotFound) == false {
+			t.Errorf("Hostinterface reachability error (%s): expected not found error, got %v", version, err)
+		}
+
+		closeMock()
+	}
+
+	t.Logf("Hostinterface reachability: success")
+}
@@ -0,0 +1,917 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// For `ItemObject` field: `Type`
+const (
+	ItemTypeZabbixAgent       = 0
+	ItemTypeZabbixTrapper     = 2
+	ItemTypeSimpleCheck       = 3
+	ItemTypeZabbixInternal    = 5
+	ItemTypeZabbixAgentActive = 7
+	ItemTypeZabbixAggregate   = 8
+	ItemTypeWebItem           = 9
+	ItemTypeExternalCheck     = 10
+	ItemTypeDatabaseMonitor   = 11
+	ItemTypeIPMIAgent         = 12
+	ItemTypeSSHAgent          = 13
+	ItemTypeTelnetAgent       = 14
+	ItemTypeCalculated        = 15
+	ItemTypeJMXAgent          = 16
+	ItemTypeSNMPTrap          = 17
+	ItemTypeDependentItem     = 18
+	ItemTypeHTTPAgent         = 19
+	ItemTypeSNMPAgent         = 20
+)
+
+// ValueType is used for `ItemObject` and `ItemprototypeObject` field: `ValueType`
+type ValueType int
+
+// For `ItemObject` field: `ValueType`
+const (
+	ItemValueTypeFloat           ValueType = 0
+	ItemValueTypeCharacter       ValueType = 1
+	ItemValueTypeLog             ValueType = 2
+	ItemValueTypeNumericUnsigned ValueType = 3
+	ItemValueTypeText            ValueType = 4
+)
+
+// For `ItemObject` field: `Flags`
+const (
+	ItemFlagsPlain      = 0
+	ItemFlagsDiscovered = 4
+)
+
+// For `ItemObject` field: `Status`
+const (
+	ItemStatusEnabled  = 0
+	ItemStatusDisabled = 1
+)
+
+// For `ItemObject` field: `State`
+const (
+	ItemStateNormal       = 0
+	ItemStateNotSupported = 1
+)
+
+// For `GetParameters` field: `SortField` (for item get requests)
+const (
+	ItemSortFieldItemID  = "itemid"
+	ItemSortFieldName    = "name"
+	ItemSortFieldKey     = "key_"
+	ItemSortFieldDelay   = "delay"
+	ItemSortFieldHistory = "history"
+	ItemSortFieldTrends  = "trends"
+	ItemSortFieldType    = "type"
+	ItemSortFieldStatus  = "status"
+)
+
+// Zabbix API version applications are removed in
+const itemApplicationsRemovedVersion = "5.4"
+
+// ItemObject struct is used to store item operations results
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/item/object
+type ItemObject struct {
+	ItemID      int       `json:"itemid,omitempty"`
+	Delay       string    `json:"delay,omitempty"`
+	HostID      int       `json:"hostid,omitempty"`
+	InterfaceID int       `json:"interfaceid,omitempty"`
+	Key         string    `json:"key_,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Type        int       `json:"type,omitempty"`       // has defined consts, see above
+	ValueType   ValueType `json:"value_type,omitempty"` // has defined consts, see above
+	Description string    `json:"description,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	Flags       int       `json:"flags,omitempty"` // has defined consts, see above
+	History     string    `json:"history,omitempty"`
+	LastClock   int       `json:"lastclock,omitempty"`
+	LastNs      int       `json:"lastns,omitempty"`
+	LastValue   string    `json:"lastvalue,omitempty"`
+	PrevValue   string    `json:"prevvalue,omitempty"`
+	State       int       `json:"state,omitempty"`  // has defined consts, see above
+	Status      int       `json:"status,omitempty"` // has defined consts, see above
+	TemplateID  int       `json:"templateid,omitempty"`
+	Trends      string    `json:"trends,omitempty"`
+	Units       string    `json:"units,omitempty"`
+	ValueMapID  int       `json:"valuemapid,omitempty"`
+
+	// Type specific fields. Zabbix API rejects fields not related to the item type,
+	// so set them only for appropriate types:
+	//   - `Params`: formula for `ItemTypeCalculated`, SQL query for `ItemTypeDatabaseMonitor`,
+	//     executed script for `ItemTypeSSHAgent` and `ItemTypeTelnetAgent`
+	//   - `SNMPOID`: `ItemTypeSNMPAgent`
+	//   - `Username`, `Password`: `ItemTypeSSHAgent`, `ItemTypeTelnetAgent`, `ItemTypeDatabaseMonitor`,
+	//     `ItemTypeJMXAgent`, `ItemTypeSimpleCheck` and `ItemTypeHTTPAgent`
+	//   - `TrapperHosts`: `ItemTypeZabbixTrapper` (and `ItemTypeHTTPAgent` with trapping allowed),
+	//     comma separated list of IP addresses (or CIDRs) and DNS names allowed to send values
+	Params       string `json:"params,omitempty"`
+	SNMPOID      string `json:"snmp_oid,omitempty"`
+	Username     string `json:"username,omitempty"`
+	Password     string `json:"password,omitempty"`
+	TrapperHosts string `json:"trapper_hosts,omitempty"`
+
+	Hosts    []HostObject    `json:"hosts,omitempty"` // for template items contains the template
+	Triggers []TriggerObject `json:"triggers,omitempty"`
+}
+
+// ItemCreateParams struct is used for item create requests. Unlike `ItemObject`, fields required by
+// Zabbix API are sent even if they have zero values (e.g. `ItemTypeZabbixAgent` or `ItemValueTypeFloat`).
+// Type specific fields must be set the same way as for `ItemObject`
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/item/create#parameters
+type ItemCreateParams struct {
+	HostID      int       `json:"hostid"`
+	Name        string    `json:"name"`
+	Key         string    `json:"key_"`
+	Type        int       `json:"type"`                  // has defined consts, see above
+	ValueType   ValueType `json:"value_type"`            // has defined consts, see above
+	Delay       string    `json:"delay,omitempty"`       // not used for e.g. `ItemTypeZabbixTrapper` and `ItemTypeDependent`
+	InterfaceID int       `json:"interfaceid,omitempty"` // required for interface dependent types only
+	Description string    `json:"description,omitempty"`
+	History     string    `json:"history,omitempty"`
+	Status      int       `json:"status,omitempty"` // has defined consts, see above
+	Trends      string    `json:"trends,omitempty"`
+	Units       string    `json:"units,omitempty"`
+	ValueMapID  int       `json:"valuemapid,omitempty"`
+
+	Params       string `json:"params,omitempty"`
+	SNMPOID      string `json:"snmp_oid,omitempty"`
+	Username     string `json:"username,omitempty"`
+	Password     string `json:"password,omitempty"`
+	TrapperHosts string `json:"trapper_hosts,omitempty"`
+}
+
+// HostName returns visible name of the item's host (or template), technical name is returned
+// if visible name is not requested. Empty string is returned if item hosts are not requested
+// (see `ItemGetParams` field `SelectHosts`)
+func (i *ItemObject) HostName() string {
+
+	if len(i.Hosts) == 0 {
+		return ""
+	}
+
+	if i.Hosts[0].Name != "" {
+		return i.Hosts[0].Name
+	}
+
+	return i.Hosts[0].Host
+}
+
+// HistoryTable returns history type (see `HistoryObjectType*` consts) `history.get` requests must be sent
+// with to get values of items with the value type. Zabbix stores values of each type in a separate table
+func (v ValueType) HistoryTable() (int, error) {
+
+	switch v {
+	case ItemValueTypeFloat:
+		return HistoryObjectTypeFloat, nil
+	case ItemValueTypeCharacter:
+		return HistoryObjectTypeCharacter, nil
+	case ItemValueTypeLog:
+		return HistoryObjectTypeLog, nil
+	case ItemValueTypeNumericUnsigned:
+		return HistoryObjectTypeNumericUnsigned, nil
+	case ItemValueTypeText:
+		return HistoryObjectTypeText, nil
+	}
+
+	return 0, fmt.Errorf("unknown item value type %d, must be within 0-4", int(v))
+}
+
+// HistoryDuration returns history storage period of the item. False is returned if period
+// can not be parsed (e.g. it is set with user macro)
+func (i *ItemObject) HistoryDuration() (time.Duration, bool) {
+
+	return durationParse(i.History)
+}
+
+// TrendsDuration returns trends storage period of the item. False is returned if period
+// can not be parsed (e.g. it is set with user macro)
+func (i *ItemObject) TrendsDuration() (time.Duration, bool) {
+
+	return durationParse(i.Trends)
+}
+
+// ItemHealth struct is used to store item counts by status and state, see `ItemHealthSummary()`
+type ItemHealth struct {
+	Enabled     int // enabled supported items
+	Disabled    int // disabled items regardless of their state
+	Unsupported int // enabled items in not supported state
+}
+
+// ItemGetParams struct is used for item get requests
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/item/get#parameters
+type ItemGetParams struct {
+	GetParameters
+
+	ItemIDs        []int `json:"itemids,omitempty"`
+	GroupIDs       []int `json:"groupids,omitempty"`
+	TemplateIDs    []int `json:"templateids,omitempty"`
+	HostIDs        []int `json:"hostids,omitempty"`
+	ProxyIDs       []int `json:"proxyids,omitempty"`
+	InterfaceIDs   []int `json:"interfaceids,omitempty"`
+	GraphIDs       []int `json:"graphids,omitempty"`
+	TriggerIDs     []int `json:"triggerids,omitempty"`
+	ApplicationIDs []int `json:"applicationids,omitempty"`
+
+	WebItems  bool `json:"webitems,omitempty"`
+	Inherited bool `json:"inherited,omitempty"`
+	Templated bool `json:"templated,omitempty"`
+	Monitored bool `json:"monitored,omitempty"`
+
+	// Name filters match exact names (not substrings): `Group` - host group name, `Host` - technical
+	// name of the host, `Application` - application name (applications are removed since Zabbix 5.4,
+	// `ItemGet()` fails if `Application` or `ApplicationIDs` are set for such versions).
+	// See also `ItemGetParamsByGroup()`, `ItemGetParamsByHost()` and `ItemGetParamsByApplication()`
+	Group       string `json:"group,omitempty"`
+	Host        string `json:"host,omitempty"`
+	Application string `json:"application,omitempty"`
+
+	WithTriggers bool `json:"with_triggers,omitempty"`
+
+	// ValueType is sent as `filter.value_type` param. Pointer is used to be able to filter
+	// by `ItemValueTypeFloat` (zero value), see also `OnlyNumeric()`
+	ValueType *ValueType `json:"-"`
+
+	SelectHosts SelectQuery `json:"selectHosts,omitempty"`
+	// SelectInterfaces    SelectQuery `json:"selectInterfaces,omitempty"` // not implemented yet
+	SelectTriggers SelectQuery `json:"selectTriggers,omitempty"`
+	// SelectGraphs        SelectQuery `json:"selectGraphs,omitempty"` // not implemented yet
+	// SelectApplications  SelectQuery `json:"selectApplications,omitempty"` // not implemented yet
+	// SelectDiscoveryRule SelectQuery `json:"selectDiscoveryRule,omitempty"` // not implemented yet
+	// SelectItemDiscovery SelectQuery `json:"selectItemDiscovery,omitempty"` // not implemented yet
+	// SelectPreprocessing SelectQuery `json:"selectPreprocessing,omitempty"` // not implemented yet
+
+	// Sort result by `lastclock` field in descending order, see `SortByLastClockDesc()`
+	sortByLastClockDesc bool
+}
+
+// ItemGetParamsByGroup returns params to get items of hosts within the host group with exact `name`
+func ItemGetParamsByGroup(name string) ItemGetParams {
+
+	return ItemGetParams{
+		Group: name,
+	}
+}
+
+// ItemGetParamsByHost returns params to get items of the host with exact technical `name`
+func ItemGetParamsByHost(name string) ItemGetParams {
+
+	return ItemGetParams{
+		Host: name,
+	}
+}
+
+// ItemGetParamsByApplication returns params to get items within the application with exact `name`.
+// Applications are available before Zabbix 5.4 only
+func ItemGetParamsByApplication(name string) ItemGetParams {
+
+	return ItemGetParams{
+		Application: name,
+	}
+}
+
+// MarshalJSON is used to put `ValueType` into `filter` param
+func (p ItemGetParams) MarshalJSON() ([]byte, error) {
+
+	type itemGetParams ItemGetParams
+
+	r := itemGetParams(p)
+
+	if p.ValueType != nil {
+		r.Filter = make(map[string]interface{})
+		for k, v := range p.Filter {
+			r.Filter[k] = v
+		}
+		r.Filter["value_type"] = *p.ValueType
+	}
+
+	return json.Marshal(r)
+}
+
+// OnlyNumeric makes `ItemGet` to return only numeric (float and unsigned) items
+func (p *ItemGetParams) OnlyNumeric() {
+
+	p.ValueType = nil
+
+	if p.Filter == nil {
+		p.Filter = make(map[string]interface{})
+	}
+
+	p.Filter["value_type"] = []ValueType{ItemValueTypeFloat, ItemValueTypeNumericUnsigned}
+}
+
+// SortByLastClockDesc makes `ItemGet` to return recently updated items first.
+// Zabbix API does not allow to sort items by `lastclock` field (see `ItemSortField*` consts
+// for allowed fields), so sorting is performed on the client side after items are received.
+// If `Limit` is set, all matching items are requested and the result is truncated after sorting,
+// so the most recently updated items are returned.
+// If `Output` is set to fields list, `lastclock` field is added to it
+func (p *ItemGetParams) SortByLastClockDesc() {
+
+	p.sortByLastClockDesc = true
+
+	var fields []string
+
+	switch o := p.Output.(type) {
+	case SelectFields:
+		fields = o
+	case []string:
+		fields = o
+	default:
+		return
+	}
+
+	for _, f := range fields {
+		if f == "lastclock" {
+			return
+		}
+	}
+
+	p.Output = SelectFields(append(fields, "lastclock"))
+}
+
+// Structure to store creation result
+type itemCreateResult struct {
+	ItemIDs []int `json:"itemids"`
+}
+
+// Structure to store updation result
+type itemUpdateResult struct {
+	ItemIDs []int `json:"itemids"`
+}
+
+// Structure to store deletion result
+type itemDeleteResult struct {
+	ItemIDs []int `json:"itemids"`
+}
+
+// ItemGet gets items
+func (z *Context) ItemGet(params ItemGetParams) ([]ItemObject, int, error) {
+	return z.ItemGetCtx(context.Background(), params)
+}
+
+// ItemGetCtx gets items within the `ctx`. Request is aborted if context is canceled
+// or its deadline is exceeded
+func (z *Context) ItemGetCtx(ctx context.Context, params ItemGetParams) ([]ItemObject, int, error) {
+
+	var result []ItemObject
+
+	if params.Application != "" || len(params.ApplicationIDs) > 0 {
+
+		b, err := z.apiVersionAtLeast(itemApplicationsRemovedVersion)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if b == true {
+			return nil, 0, fmt.Errorf("item applications are not supported since Zabbix API version %s (current version is %s), use tags instead", itemApplicationsRemovedVersion, z.apiVersionCached())
+		}
+	}
+
+	// Limit is applied by server before sorting, so it is applied after sorting instead
+	limit := 0
+	if params.sortByLastClockDesc == true {
+		limit = params.Limit
+		params.Limit = 0
+	}
+
+	status, err := z.requestCtx(ctx, "item.get", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if params.sortByLastClockDesc == true {
+		sort.SliceStable(result, func(i, j int) bool {
+			return result[i].LastClock > result[j].LastClock
+		})
+
+		if limit > 0 && len(result) > limit {
+			result = result[:limit]
+		}
+	}
+
+	return result, status, nil
+}
+
+// ItemCreate creates items
+func (z *Context) ItemCreate(params []ItemCreateParams) ([]int, int, error) {
+
+	var result itemCreateResult
+
+	status, err := z.request("item.create", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.ItemIDs, status, nil
+}
+
+// ItemUpdate updates items
+func (z *Context) ItemUpdate(params []ItemObject) ([]int, int, error) {
+
+	var result itemUpdateResult
+
+	status, err := z.request("item.update", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.ItemIDs, status, nil
+}
+
+// ItemDelete deletes items
+func (z *Context) ItemDelete(itemIDs []int) ([]int, int, error) {
+
+	var result itemDeleteResult
+
+	status, err := z.request("item.delete", itemIDs, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.ItemIDs, status, nil
+}
+
+// ItemGetProjected gets items with only specified `fields` in output and stores them into `dest`.
+// `dest` must be a pointer to slice of caller's structs, struct fields are matched by `json` tags,
+// e.g.:
+//
+//	var r []struct {
+//		ItemID int    `json:"itemid"`
+//		Key    string `json:"key_"`
+//	}
+//	_, err := z.ItemGetProjected(params, []string{"itemid", "key_"}, &r)
+func (z *Context) ItemGetProjected(params ItemGetParams, fields []string, dest interface{}) (int, error) {
+
+	params.Output = SelectFields(fields)
+
+	return z.request("item.get", params, dest)
+}
+
+// GetTemplateDivergentItems returns items of the host inherited from templates which configuration
+// (see `itemConfigDiffers()`) differs from the configuration of their parent template items
+func (z *Context) GetTemplateDivergentItems(hostID int) ([]ItemObject, error) {
+
+	hItems, _, err := z.ItemGet(ItemGetParams{
+		HostIDs:   []int{hostID},
+		Inherited: true,
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get host items error: %v", err)
+	}
+
+	var parentIDs []int
+
+	for _, i := range hItems {
+		if i.TemplateID != 0 && containsInt(parentIDs, i.TemplateID) == false {
+			parentIDs = append(parentIDs, i.TemplateID)
+		}
+	}
+
+	if len(parentIDs) == 0 {
+		return []ItemObject{}, nil
+	}
+
+	tItems, _, err := z.ItemGet(ItemGetParams{
+		ItemIDs: parentIDs,
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get template items error: %v", err)
+	}
+
+	parents := make(map[int]ItemObject)
+	for _, i := range tItems {
+		parents[i.ItemID] = i
+	}
+
+	r := []ItemObject{}
+
+	for _, i := range hItems {
+
+		p, b := parents[i.TemplateID]
+		if b == false {
+			continue
+		}
+
+		if itemConfigDiffers(i, p) == true {
+			r = append(r, i)
+		}
+	}
+
+	return r, nil
+}
+
+// GetLocalItems returns items created on the host itself, i.e. not inherited from templates
+// (`templateid` is zero). Note that discovered items (see `ItemFlagsDiscovered`) are also included
+func (z *Context) GetLocalItems(hostID int) ([]ItemObject, error) {
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{
+		HostIDs: []int{hostID},
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := []ItemObject{}
+
+	for _, i := range iObjects {
+		if i.TemplateID == 0 {
+			r = append(r, i)
+		}
+	}
+
+	return r, nil
+}
+
+// RenameItemKeys changes keys of local items of the host with `hostID` (see `GetLocalItems()`) and returns
+// IDs of the updated items. `rename` is called for every item key and returns the new key and whether
+// the key must be changed. Items inherited from templates and discovered items can not be renamed on
+// the host, so they are skipped
+func (z *Context) RenameItemKeys(hostID int, rename func(oldKey string) (newKey string, change bool)) ([]int, error) {
+
+	iObjects, err := z.GetLocalItems(hostID)
+	if err != nil {
+		return nil, fmt.Errorf("get local items error: %v", err)
+	}
+
+	var params []ItemObject
+
+	for _, i := range iObjects {
+
+		if i.Flags == ItemFlagsDiscovered {
+			continue
+		}
+
+		k, b := rename(i.Key)
+		if b == false || k == i.Key {
+			continue
+		}
+
+		params = append(params, ItemObject{
+			ItemID: i.ItemID,
+			Key:    k,
+		})
+	}
+
+	if len(params) == 0 {
+		return []int{}, nil
+	}
+
+	itemIDs, _, err := z.ItemUpdate(params)
+	if err != nil {
+		return nil, fmt.Errorf("item keys update error: %v", err)
+	}
+
+	return itemIDs, nil
+}
+
+// AuditRetention checks history and trends storage periods of all items of the template with `templateID`
+// (including items inherited from linked templates) and returns items with periods less than `minHistory`
+// or `minTrends`. Trends are checked for numeric items only. Periods which can not be parsed (set with user
+// macros) are not checked, items with such periods are returned separately as unresolved ones
+func (z *Context) AuditRetention(templateID int, minHistory, minTrends time.Duration) ([]ItemObject, []ItemObject, error) {
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{
+		TemplateIDs: []int{templateID},
+		GetParameters: GetParameters{
+			Output: SelectFields{"itemid", "name", "key_", "value_type", "history", "trends"},
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	violations := []ItemObject{}
+	unresolved := []ItemObject{}
+
+	for _, i := range iObjects {
+
+		numeric := i.ValueType == ItemValueTypeFloat || i.ValueType == ItemValueTypeNumericUnsigned
+
+		h, hOK := i.HistoryDuration()
+		t, tOK := i.TrendsDuration()
+
+		if hOK == false || (numeric == true && tOK == false) {
+			unresolved = append(unresolved, i)
+		}
+
+		if (hOK == true && h < minHistory) || (numeric == true && tOK == true && t < minTrends) {
+			violations = append(violations, i)
+		}
+	}
+
+	return violations, unresolved, nil
+}
+
+// ItemHealthSummary returns counts of enabled, disabled and unsupported items of the host with `hostID`
+// (see `ItemHealth`) within a single `item.get` request
+func (z *Context) ItemHealthSummary(hostID int) (ItemHealth, error) {
+
+	var h ItemHealth
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{
+		HostIDs: []int{hostID},
+		GetParameters: GetParameters{
+			Output: SelectFields{"itemid", "status", "state"},
+		},
+	})
+	if err != nil {
+		return h, err
+	}
+
+	for _, i := range iObjects {
+		switch {
+		case i.Status == ItemStatusDisabled:
+			h.Disabled++
+		case i.State == ItemStateNotSupported:
+			h.Unsupported++
+		default:
+			h.Enabled++
+		}
+	}
+
+	return h, nil
+}
+
+// itemConfigDiffers checks the fields of the item that may be changed on the host level
+// for the item inherited from template differ from its parent item
+func itemConfigDiffers(i, p ItemObject) bool {
+
+	if itemTimeDiffers(i.Delay, p.Delay) == true ||
+		itemTimeDiffers(i.History, p.History) == true ||
+		itemTimeDiffers(i.Trends, p.Trends) == true ||
+		i.Status != p.Status ||
+		i.Description != p.Description {
+		return true
+	}
+
+	return false
+}
+
+// itemTimeDiffers checks the time values differ. Values with time suffixes are compared in seconds
+// (e.g. `1m` equals to `60`), others (e.g. user macros or flexible intervals) are compared as is
+func itemTimeDiffers(a, b string) bool {
+
+	da, okA := durationParse(a)
+	db, okB := durationParse(b)
+
+	if okA == true && okB == true {
+		return da != db
+	}
+
+	return a != b
+}
+
+// GetItemsForTriggers returns items used in expressions of the triggers within a single `item.get` call.
+// Result is a map of trigger ID to its items, triggers referencing several items get all of them
+func (z *Context) GetItemsForTriggers(triggerIDs []int) (map[int][]ItemObject, error) {
+
+	r := make(map[int][]ItemObject)
+
+	if len(triggerIDs) == 0 {
+		return r, nil
+	}
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{
+		TriggerIDs:     triggerIDs,
+		SelectTriggers: SelectFields{"triggerid"},
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range iObjects {
+
+		triggers := i.Triggers
+		i.Triggers = nil
+
+		for _, t := range triggers {
+			if containsInt(triggerIDs, t.TriggerID) == true {
+				r[t.TriggerID] = append(r[t.TriggerID], i)
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// ItemGetRaw gets items and returns each of them as raw JSON, so caller is able to decode
+// the fields (including nested objects of selectors) not covered by `ItemObject`
+func (z *Context) ItemGetRaw(params ItemGetParams) ([]json.RawMessage, int, error) {
+
+	var result []interface{}
+
+	status, err := z.request("item.get", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	r := []json.RawMessage{}
+
+	for _, i := range result {
+
+		b, err := json.Marshal(i)
+		if err != nil {
+			return nil, status, err
+		}
+
+		r = append(r, b)
+	}
+
+	return r, status, nil
+}
+
+// CreateItemsIndividually creates items sending a separate `item.create` request per item. Unlike
+// `ItemCreate()` (where Zabbix rolls back all items if any of them fails) valid items are created even
+// if others fail. IDs of created items and failures with item indexes are returned in the result
+func (z *Context) CreateItemsIndividually(items []ItemCreateParams) BatchResult {
+
+	r := BatchResult{
+		Succeeded: []int{},
+		Failed:    []BatchFailure{},
+	}
+
+	for idx, i := range items {
+
+		itemIDs, _, err := z.ItemCreate([]ItemCreateParams{i})
+		if err == nil && len(itemIDs) == 0 {
+			err = fmt.Errorf("item create error: empty IDs array")
+		}
+
+		if err != nil {
+			r.Failed = append(r.Failed, BatchFailure{
+				Index: idx,
+				Err:   err,
+			})
+			continue
+		}
+
+		r.Succeeded = append(r.Succeeded, itemIDs[0])
+	}
+
+	return r
+}
+
+// CreateItemsAutoInterface creates `items` on the host with `hostID` and returns IDs of the created items.
+// For items of interface dependent types (Zabbix agent, SNMP, IPMI and JMX) `InterfaceID` is set to the
+// main host interface of appropriate type, unless it is already set. For other types `InterfaceID` is
+// cleared. If host has no interface required by an item, error wrapping `ErrHostinterfaceNotFound`
+// is returned and no items are created
+func (z *Context) CreateItemsAutoInterface(hostID int, items []ItemCreateParams) ([]int, error) {
+
+	hiObjects, _, err := z.HostinterfaceGet(HostinterfaceGetParams{
+		HostIDs: []int{hostID},
+		GetParameters: GetParameters{
+			Output: SelectFields{"interfaceid", "main", "type"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get host interfaces error: %v", err)
+	}
+
+	ifaces := make(map[int]int)
+	for _, hi := range hiObjects {
+		if hi.Main == HostinterfaceMainDefault {
+			ifaces[hi.Type] = hi.InterfaceID
+		}
+	}
+
+	var params []ItemCreateParams
+
+	for _, i := range items {
+
+		i.HostID = hostID
+
+		ifaceType, b := itemInterfaceType(i.Type)
+		if b == false {
+			i.InterfaceID = 0
+		} else if i.InterfaceID == 0 {
+
+			id, b := ifaces[ifaceType]
+			if b == false {
+				return nil, fmt.Errorf("%w: item `%s` requires host %d main interface of type %d", ErrHostinterfaceNotFound, i.Key, hostID, ifaceType)
+			}
+
+			i.InterfaceID = id
+		}
+
+		params = append(params, i)
+	}
+
+	itemIDs, _, err := z.ItemCreate(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return itemIDs, nil
+}
+
+// itemInterfaceType returns type of the host interface (see `HostinterfaceType*` consts)
+// required by item of the specified type
+func itemInterfaceType(itemType int) (int, bool) {
+
+	switch itemType {
+	case ItemTypeZabbixAgent:
+		return HostinterfaceTypeAgent, true
+	case ItemTypeSNMPAgent, ItemTypeSNMPTrap:
+		return HostinterfaceTypeSNMP, true
+	case ItemTypeIPMIAgent:
+		return HostinterfaceTypeIPMI, true
+	case ItemTypeJMXAgent:
+		return HostinterfaceTypeJMX, true
+	}
+
+	return 0, false
+}
+
+// GetItemsChangedSince returns items of the host received new values since specified time.
+// Zabbix API does not track items modification time, so `lastclock` (time of the last received value)
+// is used instead and filtering is performed on the client side. Note that items which configuration
+// was changed but no new values were received are not returned, and items with history storage
+// disabled (`lastclock` is not available) are never returned
+func (z *Context) GetItemsChangedSince(hostID int, since time.Time) ([]ItemObject, error) {
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{
+		HostIDs: []int{hostID},
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := []ItemObject{}
+
+	for _, i := range iObjects {
+		if int64(i.LastClock) >= since.Unix() {
+			r = append(r, i)
+		}
+	}
+
+	return r, nil
+}
+
+// QuoteItemKeyParam returns item key parameter wrapped in double quotes with embedded double quotes
+// escaped by backslashes. Other characters (including backslashes) are kept as is, since Zabbix treats
+// only `\"` sequence as an escape within quoted parameters. For the same reason parameter ending with
+// a backslash can not be quoted (the closing quote would be escaped), error is returned in this case
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/config/items/item/key
+func QuoteItemKeyParam(s string) (string, error) {
+
+	if strings.HasSuffix(s, `\`) == true {
+		return "", fmt.Errorf("item key parameter `%s` ends with backslash and can not be quoted", s)
+	}
+
+	return `"` + strings.Replace(s, `"`, `\"`, -1) + `"`, nil
+}
+
+// BuildItemKey builds item key from `name` and `params`. Parameters containing characters
+// having special meaning in item keys (commas, square brackets, quotes or leading spaces)
+// are quoted with `QuoteItemKeyParam()` (error is returned if parameter can not be quoted), e.g.:
+//
+//	BuildItemKey("vfs.fs.size", "/", "pfree")       // vfs.fs.size[/,pfree]
+//	BuildItemKey("custom.key", "a,b", `say "hi"`) // custom.key["a,b","say \"hi\""]
+func BuildItemKey(name string, params ...string) (string, error) {
+
+	if len(params) == 0 {
+		return name, nil
+	}
+
+	var p []string
+
+	for _, s := range params {
+		if strings.ContainsAny(s, `,[]"`) == true || strings.HasPrefix(s, " ") == true {
+			q, err := QuoteItemKeyParam(s)
+			if err != nil {
+				return "", err
+			}
+			s = q
+		}
+		p = append(p, s)
+	}
+
+	return name + "[" + strings.Join(p, ",") + "]", nil
+}
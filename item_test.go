@@ -0,0 +1,1568 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testItemKey = "test.item"
+)
+
+func TestItemCRUD(t *testing.T) {
+
+	var z Context
+
+	// Login
+	loginTest(&z, t)
+	defer logoutTest(&z, t)
+
+	// Preparing auxiliary data
+	hgCreatedIDs := testHostgroupCreate(t, z)
+	defer testHostgroupDelete(t, z, hgCreatedIDs)
+
+	tCreatedIDs := testTemplateCreate(t, z, hgCreatedIDs)
+	defer testTemplateDelete(t, z, tCreatedIDs)
+
+	hCreatedIDs := testHostCreate(t, z, hgCreatedIDs, tCreatedIDs)
+	defer testHostDelete(t, z, hCreatedIDs)
+
+	// Create and delete
+	iCreatedIDs := testItemCreate(t, z, hCreatedIDs[0])
+	defer testItemDelete(t, z, iCreatedIDs)
+
+	// Update
+	testItemUpdate(t, z, iCreatedIDs)
+}
+
+func TestItemGetProjected(t *testing.T) {
+
+	var output []string
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p struct {
+			Output []string `json:"output"`
+		}
+
+		if method != "item.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		output = p.Output
+
+		return []map[string]interface{}{
+			{"itemid": "1001", "key_": testItemKey},
+		}, nil
+	})
+	defer closeMock()
+
+	var iObjects []struct {
+		ItemID int    `json:"itemid"`
+		Key    string `json:"key_"`
+	}
+
+	if _, err := z.ItemGetProjected(ItemGetParams{}, []string{"itemid", "key_"}, &iObjects); err != nil {
+		t.Fatal("Item get projected error:", err)
+	}
+
+	if reflect.DeepEqual(output, []string{"itemid", "key_"}) == false {
+		t.Errorf("Item get projected error: unexpected output param %v", output)
+	}
+
+	if len(iObjects) != 1 || iObjects[0].ItemID != 1001 || iObjects[0].Key != testItemKey {
+		t.Errorf("Item get projected error: unexpected result %v", iObjects)
+	}
+
+	t.Logf("Item get projected: success")
+}
+
+func TestItemSortByLastClockDesc(t *testing.T) {
+
+	var sent map[string]interface{}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		sent = nil
+
+		if err := json.Unmarshal(params, &sent); err != nil {
+			return nil, err
+		}
+
+		return []map[string]interface{}{
+			{"itemid": "1", "lastclock": "100"},
+			{"itemid": "2", "lastclock": "300"},
+			{"itemid": "3", "lastclock": "200"},
+		}, nil
+	})
+	defer closeMock()
+
+	params := ItemGetParams{
+		GetParameters: GetParameters{
+			Output: SelectFields{"itemid"},
+		},
+	}
+	params.SortByLastClockDesc()
+
+	iObjects, _, err := z.ItemGet(params)
+	if err != nil {
+		t.Fatal("Item sort by lastclock error:", err)
+	}
+
+	if _, b := sent["sortfield"]; b == true {
+		t.Errorf("Item sort by lastclock error: unexpected sortfield param %v", sent["sortfield"])
+	}
+
+	if reflect.DeepEqual(sent["output"], []interface{}{"itemid", "lastclock"}) == false {
+		t.Errorf("Item sort by lastclock error: unexpected output param %v", sent["output"])
+	}
+
+	var ids []int
+	for _, i := range iObjects {
+		ids = append(ids, i.ItemID)
+	}
+
+	if reflect.DeepEqual(ids, []int{2, 3, 1}) == false {
+		t.Errorf("Item sort by lastclock error: unexpected items order %v", ids)
+	}
+
+	// Limit is applied after sorting
+	params.Limit = 2
+
+	iObjects, _, err = z.ItemGet(params)
+	if err != nil {
+		t.Fatal("Item sort by lastclock error:", err)
+	}
+
+	if _, b := sent["limit"]; b == true {
+		t.Errorf("Item sort by lastclock error: unexpected limit param %v", sent["limit"])
+	}
+
+	ids = nil
+	for _, i := range iObjects {
+		ids = append(ids, i.ItemID)
+	}
+
+	if reflect.DeepEqual(ids, []int{2, 3}) == false {
+		t.Errorf("Item sort by lastclock error: unexpected limited items %v", ids)
+	}
+
+	t.Logf("Item sort by lastclock: success")
+}
+
+func TestItemValueTypeFilter(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		params   func() ItemGetParams
+		expected string
+	}{
+		{
+			name: "numeric",
+			params: func() ItemGetParams {
+				p := ItemGetParams{}
+				p.OnlyNumeric()
+				return p
+			},
+			expected: `{"value_type":[0,3]}`,
+		},
+		{
+			name: "float",
+			params: func() ItemGetParams {
+				v := ItemValueTypeFloat
+				return ItemGetParams{
+					ValueType: &v,
+					GetParameters: GetParameters{
+						Filter: map[string]interface{}{
+							"key_": testItemKey,
+						},
+					},
+				}
+			},
+			expected: `{"key_":"test.item","value_type":0}`,
+		},
+	}
+
+	for _, tt := range tests {
+
+		b, err := json.Marshal(tt.params())
+		if err != nil {
+			t.Fatalf("Item value type filter error (%s): %v", tt.name, err)
+		}
+
+		var p struct {
+			Filter json.RawMessage `json:"filter"`
+		}
+
+		if err := json.Unmarshal(b, &p); err != nil {
+			t.Fatalf("Item value type filter error (%s): %v", tt.name, err)
+		}
+
+		if string(p.Filter) != tt.expected {
+			t.Errorf("Item value type filter error (%s): unexpected filter %s", tt.name, string(p.Filter))
+		}
+	}
+
+	t.Logf("Item value type filter: success")
+}
+
+func TestItemTemplateDivergent(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p struct {
+			HostIDs []int `json:"hostids"`
+			ItemIDs []int `json:"itemids"`
+		}
+
+		if method != "item.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		// Host items
+		if len(p.HostIDs) > 0 {
+			return []map[string]interface{}{
+				{"itemid": "2001", "templateid": "1001", "key_": "agent.ping", "delay": "5m", "history": "90d", "trends": "365d", "status": "0"},
+				// Equivalent time values in different units
+				{"itemid": "2002", "templateid": "1002", "key_": "system.uptime", "delay": "60", "history": "2160h", "trends": "8760h", "status": "0"},
+				{"itemid": "2003", "templateid": "1003", "key_": "system.cpu.load", "delay": "{$DELAY}", "history": "1w", "trends": "365d", "status": "0"},
+			}, nil
+		}
+
+		// Template items
+		if reflect.DeepEqual(p.ItemIDs, []int{1001, 1002, 1003}) == false {
+			return nil, fmt.Errorf("unexpected itemids %v", p.ItemIDs)
+		}
+
+		return []map[string]interface{}{
+			{"itemid": "1001", "key_": "agent.ping", "delay": "1m", "history": "90d", "trends": "365d", "status": "0"},
+			{"itemid": "1002", "key_": "system.uptime", "delay": "1m", "history": "90d", "trends": "365d", "status": "0"},
+			{"itemid": "1003", "key_": "system.cpu.load", "delay": "{$DELAY}", "history": "7d", "trends": "365d", "status": "0"},
+		}, nil
+	})
+	defer closeMock()
+
+	iObjects, err := z.GetTemplateDivergentItems(10084)
+	if err != nil {
+		t.Fatal("Item template divergent error:", err)
+	}
+
+	if len(iObjects) != 1 || iObjects[0].ItemID != 2001 {
+		t.Errorf("Item template divergent error: unexpected result %v", iObjects)
+	}
+
+	t.Logf("Item template divergent: success")
+}
+
+func TestItemChangedSince(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "item.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return []map[string]interface{}{
+			{"itemid": "1", "lastclock": "1590000000"},
+			{"itemid": "2", "lastclock": "1590000100"},
+			{"itemid": "3", "lastclock": "1590000200"},
+			{"itemid": "4", "lastclock": "0"},
+		}, nil
+	})
+	defer closeMock()
+
+	iObjects, err := z.GetItemsChangedSince(10084, time.Unix(1590000100, 0))
+	if err != nil {
+		t.Fatal("Item changed since error:", err)
+	}
+
+	var ids []int
+	for _, i := range iObjects {
+		ids = append(ids, i.ItemID)
+	}
+
+	if reflect.DeepEqual(ids, []int{2, 3}) == false {
+		t.Errorf("Item changed since error: unexpected items %v", ids)
+	}
+
+	t.Logf("Item changed since: success")
+}
+
+func TestItemLocalItems(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "item.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return []map[string]interface{}{
+			{"itemid": "2001", "templateid": "1001", "key_": "agent.ping"},
+			{"itemid": "2002", "templateid": "0", "key_": testItemKey},
+			{"itemid": "2003", "templateid": "1003", "key_": "system.uptime"},
+		}, nil
+	})
+	defer closeMock()
+
+	iObjects, err := z.GetLocalItems(10084)
+	if err != nil {
+		t.Fatal("Item local items error:", err)
+	}
+
+	if len(iObjects) != 1 || iObjects[0].ItemID != 2002 || iObjects[0].Key != testItemKey {
+		t.Errorf("Item local items error: unexpected result %v", iObjects)
+	}
+
+	t.Logf("Item local items: success")
+}
+
+func TestItemRenameKeys(t *testing.T) {
+
+	var updated []map[string]interface{}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "item.get":
+
+			return []map[string]interface{}{
+				{"itemid": "2001", "templateid": "1001", "key_": "agent.ping", "flags": "0"},
+				{"itemid": "2002", "templateid": "0", "key_": "app.requests", "flags": "0"},
+				{"itemid": "2003", "templateid": "0", "key_": "app.errors", "flags": "0"},
+				{"itemid": "2004", "templateid": "0", "key_": "app.disk[/]", "flags": "4"},
+				{"itemid": "2005", "templateid": "0", "key_": "myapp.latency", "flags": "0"},
+			}, nil
+
+		case "item.update":
+
+			if err := json.Unmarshal(params, &updated); err != nil {
+				return nil, err
+			}
+
+			var ids []string
+			for _, u := range updated {
+				ids = append(ids, fmt.Sprint(u["itemid"]))
+			}
+
+			return map[string]interface{}{"itemids": ids}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	itemIDs, err := z.RenameItemKeys(10084, func(oldKey string) (string, bool) {
+
+		if strings.HasPrefix(oldKey, "myapp.") == true {
+			return oldKey, false
+		}
+
+		return "myapp." + strings.TrimPrefix(oldKey, "app."), true
+	})
+	if err != nil {
+		t.Fatal("Item rename keys error:", err)
+	}
+
+	if reflect.DeepEqual(itemIDs, []int{2002, 2003}) == false {
+		t.Errorf("Item rename keys error: unexpected IDs %v", itemIDs)
+	}
+
+	if len(updated) != 2 || updated[0]["key_"] != "myapp.requests" || updated[1]["key_"] != "myapp.errors" {
+		t.Errorf("Item rename keys error: unexpected params %v", updated)
+	}
+
+	t.Logf("Item rename keys: success")
+}
+
+func TestItemExcludeSearch(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "item.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		var p struct {
+			Search        map[string]string `json:"search"`
+			ExcludeSearch bool              `json:"excludeSearch"`
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		if p.Search["key_"] != "vfs." || p.ExcludeSearch == false {
+			return nil, fmt.Errorf("unexpected params %s", string(params))
+		}
+
+		items := []map[string]interface{}{
+			{"itemid": "2001", "key_": "agent.ping"},
+			{"itemid": "2002", "key_": "vfs.fs.size[/,free]"},
+			{"itemid": "2003", "key_": "system.uptime"},
+		}
+
+		r := []map[string]interface{}{}
+		for _, i := range items {
+			if strings.Contains(i["key_"].(string), p.Search["key_"]) == false {
+				r = append(r, i)
+			}
+		}
+
+		return r, nil
+	})
+	defer closeMock()
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{
+		GetParameters: GetParameters{
+			Search: map[string]string{
+				"key_": "vfs.",
+			},
+			ExcludeSearch: true,
+			Output:        SelectFields{"itemid", "key_"},
+		},
+	})
+	if err != nil {
+		t.Fatal("Item exclude search error:", err)
+	}
+
+	if len(iObjects) != 2 || iObjects[0].ItemID != 2001 || iObjects[1].ItemID != 2003 {
+		t.Errorf("Item exclude search error: unexpected items %v", iObjects)
+	}
+
+	t.Logf("Item exclude search: success")
+}
+
+func TestItemAuditRetention(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "item.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		var p ItemGetParams
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		if reflect.DeepEqual(p.TemplateIDs, []int{10001}) == false {
+			return nil, fmt.Errorf("unexpected params %s", string(params))
+		}
+
+		return []map[string]interface{}{
+			{"itemid": "2001", "key_": "system.cpu.load", "value_type": "0", "history": "1d", "trends": "365d"},
+			{"itemid": "2002", "key_": "system.uptime", "value_type": "3", "history": "2w", "trends": "365d"},
+			{"itemid": "2003", "key_": "agent.version", "value_type": "1", "history": "30d", "trends": "0"},
+			{"itemid": "2004", "key_": "net.if.in[eth0]", "value_type": "3", "history": "{$HISTORY}", "trends": "180d"},
+			{"itemid": "2005", "key_": "net.if.out[eth0]", "value_type": "3", "history": "7d", "trends": "30d"},
+		}, nil
+	})
+	defer closeMock()
+
+	violations, unresolved, err := z.AuditRetention(10001, 7*24*time.Hour, 90*24*time.Hour)
+	if err != nil {
+		t.Fatal("Item audit retention error:", err)
+	}
+
+	var ids []int
+	for _, i := range violations {
+		ids = append(ids, i.ItemID)
+	}
+
+	if reflect.DeepEqual(ids, []int{2001, 2005}) == false {
+		t.Errorf("Item audit retention error: unexpected violations %v", ids)
+	}
+
+	if len(unresolved) != 1 || unresolved[0].ItemID != 2004 {
+		t.Errorf("Item audit retention error: unexpected unresolved items %v", unresolved)
+	}
+
+	t.Logf("Item audit retention: success")
+}
+
+func TestItemNameFilters(t *testing.T) {
+
+	var (
+		version string
+		calls   int
+	)
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "apiinfo.version":
+			return version, nil
+		case "item.get":
+
+			calls++
+
+			var p map[string]interface{}
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			if _, b := p["search"]; b == true {
+				return nil, fmt.Errorf("unexpected search param %s", string(params))
+			}
+
+			items := []map[string]interface{}{
+				{"itemid": "2001", "host": "Zabbix server", "application": "CPU"},
+				{"itemid": "2002", "host": "Zabbix server 2", "application": "CPU load"},
+			}
+
+			// Exact match as Zabbix does
+			r := []map[string]interface{}{}
+			for _, i := range items {
+				if (p["host"] == nil || p["host"] == i["host"]) && (p["application"] == nil || p["application"] == i["application"]) {
+					r = append(r, map[string]interface{}{"itemid": i["itemid"]})
+				}
+			}
+
+			return r, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	iObjects, _, err := z.ItemGet(ItemGetParamsByHost("Zabbix server"))
+	if err != nil {
+		t.Fatal("Item name filters error:", err)
+	}
+
+	if len(iObjects) != 1 || iObjects[0].ItemID != 2001 {
+		t.Errorf("Item name filters error: unexpected items %v", iObjects)
+	}
+
+	if p := ItemGetParamsByGroup("Linux servers"); p.Group != "Linux servers" {
+		t.Errorf("Item name filters error: unexpected group params %v", p)
+	}
+
+	// Applications before Zabbix 5.4
+	version = "5.0.2"
+
+	iObjects, _, err = z.ItemGet(ItemGetParamsByApplication("CPU"))
+	if err != nil {
+		t.Fatal("Item name filters error:", err)
+	}
+
+	if len(iObjects) != 1 || iObjects[0].ItemID != 2001 {
+		t.Errorf("Item name filters error: unexpected items %v", iObjects)
+	}
+
+	// Applications since Zabbix 5.4
+	z.apiVersion = ""
+	version = "5.4.0"
+	calls = 0
+
+	if _, _, err := z.ItemGet(ItemGetParamsByApplication("CPU")); err == nil || calls != 0 {
+		t.Errorf("Item name filters error: expected version error, got %v (requests: %d)", err, calls)
+	}
+
+	t.Logf("Item name filters: success")
+}
+
+func TestItemHealthSummary(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "item.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		var p ItemGetParams
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		if reflect.DeepEqual(p.HostIDs, []int{10084}) == false {
+			return nil, fmt.Errorf("unexpected params %s", string(params))
+		}
+
+		return []map[string]interface{}{
+			{"itemid": "2001", "status": "0", "state": "0"},
+			{"itemid": "2002", "status": "0", "state": "0"},
+			{"itemid": "2003", "status": "0", "state": "0"},
+			{"itemid": "2004", "status": "1", "state": "0"},
+			{"itemid": "2005", "status": "1", "state": "1"},
+			{"itemid": "2006", "status": "0", "state": "1"},
+		}, nil
+	})
+	defer closeMock()
+
+	h, err := z.ItemHealthSummary(10084)
+	if err != nil {
+		t.Fatal("Item health summary error:", err)
+	}
+
+	if h != (ItemHealth{Enabled: 3, Disabled: 2, Unsupported: 1}) {
+		t.Errorf("Item health summary error: unexpected summary %+v", h)
+	}
+
+	t.Logf("Item health summary: success")
+}
+
+func TestItemValueTypeHistoryTable(t *testing.T) {
+
+	tests := map[ValueType]int{
+		ItemValueTypeFloat:           HistoryObjectTypeFloat,
+		ItemValueTypeCharacter:       HistoryObjectTypeCharacter,
+		ItemValueTypeLog:             HistoryObjectTypeLog,
+		ItemValueTypeNumericUnsigned: HistoryObjectTypeNumericUnsigned,
+		ItemValueTypeText:            HistoryObjectTypeText,
+	}
+
+	for v, expected := range tests {
+
+		h, err := v.HistoryTable()
+		if err != nil {
+			t.Fatalf("Item value type history table error (%d): %v", v, err)
+		}
+
+		if h != expected {
+			t.Errorf("Item value type history table error (%d): expected %d, got %d", v, expected, h)
+		}
+	}
+
+	for _, v := range []ValueType{-1, 5} {
+		if _, err := v.HistoryTable(); err == nil {
+			t.Errorf("Item value type history table error (%d): expected validation error", v)
+		}
+	}
+
+	// Out of range history type is rejected before request
+	var z Context
+
+	if _, err := z.GetHistory(5, HistoryGetParams{ItemIDs: []int{23296}}); err == nil {
+		t.Error("Item value type history table error: expected history type validation error")
+	}
+
+	t.Logf("Item value type history table: success")
+}
+
+func TestItemStorageDuration(t *testing.T) {
+
+	tests := []struct {
+		value    string
+		duration time.Duration
+		ok       bool
+	}{
+		{
+			value:    "7d",
+			duration: 7 * 24 * time.Hour,
+			ok:       true,
+		},
+		{
+			value:    "2w",
+			duration: 14 * 24 * time.Hour,
+			ok:       true,
+		},
+		{
+			value:    "12h",
+			duration: 12 * time.Hour,
+			ok:       true,
+		},
+		{
+			value:    "0",
+			duration: 0,
+			ok:       true,
+		},
+		{
+			value:    "{$HIST}",
+			duration: 0,
+			ok:       false,
+		},
+	}
+
+	for _, tt := range tests {
+
+		i := ItemObject{
+			History: tt.value,
+			Trends:  tt.value,
+		}
+
+		if d, ok := i.HistoryDuration(); d != tt.duration || ok != tt.ok {
+			t.Errorf("Item storage duration error (%s): unexpected history duration %v, %v", tt.value, d, ok)
+		}
+
+		if d, ok := i.TrendsDuration(); d != tt.duration || ok != tt.ok {
+			t.Errorf("Item storage duration error (%s): unexpected trends duration %v, %v", tt.value, d, ok)
+		}
+	}
+
+	t.Logf("Item storage duration: success")
+}
+
+func TestItemGetRaw(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "item.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return []map[string]interface{}{
+			{
+				"itemid": "1001",
+				"key_":   testItemKey,
+				"preprocessing": []map[string]interface{}{
+					{"type": "5", "params": "^(\\d+)$\n\\1"},
+				},
+			},
+		}, nil
+	})
+	defer closeMock()
+
+	raw, _, err := z.ItemGetRaw(ItemGetParams{})
+	if err != nil {
+		t.Fatal("Item get raw error:", err)
+	}
+
+	if len(raw) != 1 {
+		t.Fatalf("Item get raw error: unexpected result %v", raw)
+	}
+
+	var i struct {
+		ItemID        string `json:"itemid"`
+		Key           string `json:"key_"`
+		Preprocessing []struct {
+			Type   string `json:"type"`
+			Params string `json:"params"`
+		} `json:"preprocessing"`
+	}
+
+	if err := json.Unmarshal(raw[0], &i); err != nil {
+		t.Fatal("Item get raw error:", err)
+	}
+
+	if i.ItemID != "1001" || i.Key != testItemKey || len(i.Preprocessing) != 1 || i.Preprocessing[0].Type != "5" {
+		t.Errorf("Item get raw error: unexpected item %s", string(raw[0]))
+	}
+
+	t.Logf("Item get raw: success")
+}
+
+func TestItemNumericIDs(t *testing.T) {
+
+	// ID exceeding float64 precision
+	var bigID int64 = 9007199254740993
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "item.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return []map[string]interface{}{
+			{"itemid": "1001", "hostid": "10084", "lastclock": "1590000000"},
+			{"itemid": 1002, "hostid": 10084, "lastclock": 1590000000},
+			{"itemid": json.Number("9007199254740993"), "hostid": 10084, "lastclock": 1590000000.5},
+		}, nil
+	})
+	defer closeMock()
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{})
+	if err != nil {
+		t.Fatal("Item numeric IDs error:", err)
+	}
+
+	var ids []int
+	for _, i := range iObjects {
+		if i.HostID != 10084 || i.LastClock != 1590000000 {
+			t.Errorf("Item numeric IDs error: unexpected item %v", i)
+		}
+		ids = append(ids, i.ItemID)
+	}
+
+	if reflect.DeepEqual(ids, []int{1001, 1002, int(bigID)}) == false {
+		t.Errorf("Item numeric IDs error: unexpected IDs %v", ids)
+	}
+
+	t.Logf("Item numeric IDs: success")
+}
+
+func TestItemForTriggers(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p ItemGetParams
+
+		if method != "item.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		if reflect.DeepEqual(p.TriggerIDs, []int{101, 102}) == false {
+			return nil, fmt.Errorf("unexpected triggerids %v", p.TriggerIDs)
+		}
+
+		return []map[string]interface{}{
+			{"itemid": "1001", "key_": "net.if.in[eth0]", "triggers": []map[string]interface{}{{"triggerid": "101"}}},
+			{"itemid": "1002", "key_": "net.if.out[eth0]", "triggers": []map[string]interface{}{{"triggerid": "101"}, {"triggerid": "103"}}},
+			{"itemid": "1003", "key_": "agent.ping", "triggers": []map[string]interface{}{{"triggerid": "102"}}},
+		}, nil
+	})
+	defer closeMock()
+
+	m, err := z.GetItemsForTriggers([]int{101, 102})
+	if err != nil {
+		t.Fatal("Item for triggers error:", err)
+	}
+
+	ids := make(map[int][]int)
+	for triggerID, iObjects := range m {
+		for _, i := range iObjects {
+			ids[triggerID] = append(ids[triggerID], i.ItemID)
+		}
+	}
+
+	expected := map[int][]int{
+		101: {1001, 1002},
+		102: {1003},
+	}
+
+	if reflect.DeepEqual(ids, expected) == false {
+		t.Errorf("Item for triggers error: unexpected result %v", ids)
+	}
+
+	t.Logf("Item for triggers: success")
+}
+
+func TestItemCreateParams(t *testing.T) {
+
+	var (
+		created []map[string]interface{}
+		deleted []int
+	)
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "item.create":
+
+			if err := json.Unmarshal(params, &created); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"itemids": []string{"2001"}}, nil
+
+		case "item.delete":
+
+			if err := json.Unmarshal(params, &deleted); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"itemids": []string{"2001"}}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	iCreatedIDs, _, err := z.ItemCreate([]ItemCreateParams{
+		{
+			HostID:      10001,
+			Name:        "Load average",
+			Key:         "system.cpu.load",
+			Type:        ItemTypeZabbixAgent,
+			ValueType:   ItemValueTypeFloat,
+			Delay:       "1m",
+			InterfaceID: 30,
+		},
+	})
+	if err != nil {
+		t.Fatal("Item create params error:", err)
+	}
+
+	if reflect.DeepEqual(iCreatedIDs, []int{2001}) == false {
+		t.Errorf("Item create params error: unexpected IDs %v", iCreatedIDs)
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"hostid":      float64(10001),
+			"name":        "Load average",
+			"key_":        "system.cpu.load",
+			"type":        float64(ItemTypeZabbixAgent),
+			"value_type":  float64(ItemValueTypeFloat),
+			"delay":       "1m",
+			"interfaceid": float64(30),
+		},
+	}
+
+	if reflect.DeepEqual(created, expected) == false {
+		t.Errorf("Item create params error: unexpected params %v", created)
+	}
+
+	iDeletedIDs, _, err := z.ItemDelete(iCreatedIDs)
+	if err != nil {
+		t.Fatal("Item create params error:", err)
+	}
+
+	if reflect.DeepEqual(iDeletedIDs, []int{2001}) == false || reflect.DeepEqual(deleted, []int{2001}) == false {
+		t.Errorf("Item create params error: unexpected deleted IDs %v", iDeletedIDs)
+	}
+
+	t.Logf("Item create params: success")
+}
+
+func TestItemCreateCalculated(t *testing.T) {
+
+	var created []map[string]interface{}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "item.create":
+
+			if err := json.Unmarshal(params, &created); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"itemids": []string{"2001"}}, nil
+
+		case "item.get":
+
+			return []map[string]interface{}{
+				{"itemid": "2001", "type": "15", "params": "last(//test.item)*2", "snmp_oid": ""},
+			}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	iCreatedIDs, _, err := z.ItemCreate([]ItemCreateParams{
+		{
+			HostID:    10001,
+			Name:      "Calculated item",
+			Key:       "test.calculated",
+			Type:      ItemTypeCalculated,
+			ValueType: ItemValueTypeFloat,
+			Delay:     "1m",
+			Params:    "last(//test.item)*2",
+		},
+	})
+	if err != nil {
+		t.Fatal("Item create calculated error:", err)
+	}
+
+	if reflect.DeepEqual(iCreatedIDs, []int{2001}) == false {
+		t.Errorf("Item create calculated error: unexpected IDs %v", iCreatedIDs)
+	}
+
+	if len(created) != 1 || created[0]["params"] != "last(//test.item)*2" {
+		t.Fatalf("Item create calculated error: unexpected params %v", created)
+	}
+
+	// Zero value type must be sent as it is required by Zabbix API
+	if created[0]["type"] != float64(ItemTypeCalculated) || created[0]["value_type"] != float64(ItemValueTypeFloat) {
+		t.Errorf("Item create calculated error: unexpected type or value type %v", created[0])
+	}
+
+	for _, f := range []string{"snmp_oid", "username", "password"} {
+		if _, ok := created[0][f]; ok == true {
+			t.Errorf("Item create calculated error: unexpected field `%s` sent", f)
+		}
+	}
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{ItemIDs: iCreatedIDs})
+	if err != nil {
+		t.Fatal("Item create calculated error:", err)
+	}
+
+	if len(iObjects) != 1 || iObjects[0].Type != ItemTypeCalculated || iObjects[0].Params != "last(//test.item)*2" {
+		t.Errorf("Item create calculated error: unexpected items %v", iObjects)
+	}
+
+	t.Logf("Item create calculated: success")
+}
+
+func TestItemCreateIndividually(t *testing.T) {
+
+	var calls int
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "item.create" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		var p []ItemObject
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		calls++
+
+		if len(p) != 1 {
+			return nil, fmt.Errorf("unexpected items count %d", len(p))
+		}
+
+		if p[0].Key == "" {
+			return nil, fmt.Errorf("Invalid parameter \"/1\": the parameter \"key_\" is missing.")
+		}
+
+		return map[string]interface{}{"itemids": []string{fmt.Sprintf("%d", 2000+calls)}}, nil
+	})
+	defer closeMock()
+
+	r := z.CreateItemsIndividually([]ItemCreateParams{
+		{HostID: 10001, Name: "First", Key: "test.first", Type: ItemTypeZabbixTrapper},
+		{HostID: 10001, Name: "Second", Type: ItemTypeZabbixTrapper},
+		{HostID: 10001, Name: "Third", Key: "test.third", Type: ItemTypeZabbixTrapper},
+	})
+
+	if calls != 3 {
+		t.Errorf("Item create individually error: unexpected requests count %d", calls)
+	}
+
+	if reflect.DeepEqual(r.Succeeded, []int{2001, 2003}) == false {
+		t.Errorf("Item create individually error: unexpected succeeded IDs %v", r.Succeeded)
+	}
+
+	if len(r.Failed) != 1 || r.Failed[0].Index != 1 || r.Failed[0].Err == nil {
+		t.Fatalf("Item create individually error: unexpected failures %v", r.Failed)
+	}
+
+	var e *ZabbixError
+	if errors.As(r.Failed[0].Err, &e) == false {
+		t.Errorf("Item create individually error: unexpected failure error %v", r.Failed[0].Err)
+	}
+
+	t.Logf("Item create individually: success")
+}
+
+func TestItemCreateAutoInterface(t *testing.T) {
+
+	var created []map[string]interface{}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "hostinterface.get":
+
+			return []map[string]interface{}{
+				{"interfaceid": "30", "type": "1", "main": "1"},
+				{"interfaceid": "31", "type": "2", "main": "0"},
+				{"interfaceid": "32", "type": "2", "main": "1"},
+			}, nil
+
+		case "item.create":
+
+			if err := json.Unmarshal(params, &created); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"itemids": []string{"2001", "2002", "2003"}}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	iCreatedIDs, err := z.CreateItemsAutoInterface(10001, []ItemCreateParams{
+		{
+			Name:      "Uptime",
+			Key:       "sysUpTime",
+			Type:      ItemTypeSNMPAgent,
+			ValueType: ItemValueTypeNumericUnsigned,
+			Delay:     "1m",
+			SNMPOID:   "1.3.6.1.2.1.1.3.0",
+		},
+		{
+			Name:      "Trapper",
+			Key:       "test.trapper",
+			Type:      ItemTypeZabbixTrapper,
+			ValueType: ItemValueTypeText,
+		},
+		{
+			Name:      "Load average",
+			Key:       "system.cpu.load",
+			Type:      ItemTypeZabbixAgent,
+			ValueType: ItemValueTypeFloat,
+			Delay:     "1m",
+		},
+	})
+	if err != nil {
+		t.Fatal("Item create auto interface error:", err)
+	}
+
+	if reflect.DeepEqual(iCreatedIDs, []int{2001, 2002, 2003}) == false {
+		t.Errorf("Item create auto interface error: unexpected IDs %v", iCreatedIDs)
+	}
+
+	if len(created) != 3 || created[0]["interfaceid"] != float64(32) || created[0]["hostid"] != float64(10001) {
+		t.Fatalf("Item create auto interface error: unexpected params %v", created)
+	}
+
+	if _, ok := created[1]["interfaceid"]; ok == true {
+		t.Errorf("Item create auto interface error: unexpected interface for trapper item %v", created[1])
+	}
+
+	if created[2]["interfaceid"] != float64(30) || created[2]["type"] != float64(ItemTypeZabbixAgent) || created[2]["value_type"] != float64(ItemValueTypeFloat) {
+		t.Errorf("Item create auto interface error: unexpected params for agent item %v", created[2])
+	}
+
+	_, err = z.CreateItemsAutoInterface(10001, []ItemCreateParams{
+		{
+			Name: "Heap memory",
+			Key:  "jmx[\"java.lang:type=Memory\",HeapMemoryUsage.used]",
+			Type: ItemTypeJMXAgent,
+		},
+	})
+	if errors.Is(err, ErrHostinterfaceNotFound) == false {
+		t.Errorf("Item create auto interface error: unexpected error for missing interface: %v", err)
+	}
+
+	t.Logf("Item create auto interface: success")
+}
+
+func TestItemTrapperHosts(t *testing.T) {
+
+	var created []map[string]interface{}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "item.create":
+
+			if err := json.Unmarshal(params, &created); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"itemids": []string{"2001", "2002"}}, nil
+
+		case "item.get":
+
+			return []map[string]interface{}{
+				{"itemid": "2001", "type": "2", "trapper_hosts": "10.1.1.0/24,collector.example.com"},
+			}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	iCreatedIDs, _, err := z.ItemCreate([]ItemCreateParams{
+		{
+			HostID:       10001,
+			Name:         "Trapper item",
+			Key:          "test.trapper",
+			Type:         ItemTypeZabbixTrapper,
+			ValueType:    ItemValueTypeText,
+			TrapperHosts: "10.1.1.0/24,collector.example.com",
+		},
+		{
+			HostID:    10001,
+			Name:      "Calculated item",
+			Key:       "test.calculated",
+			Type:      ItemTypeCalculated,
+			ValueType: ItemValueTypeFloat,
+			Params:    "last(//test.item)*2",
+		},
+	})
+	if err != nil {
+		t.Fatal("Item trapper hosts error:", err)
+	}
+
+	if len(created) != 2 || created[0]["trapper_hosts"] != "10.1.1.0/24,collector.example.com" {
+		t.Fatalf("Item trapper hosts error: unexpected params %v", created)
+	}
+
+	if _, ok := created[1]["trapper_hosts"]; ok == true {
+		t.Errorf("Item trapper hosts error: unexpected field `trapper_hosts` sent for calculated item")
+	}
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{ItemIDs: iCreatedIDs[:1]})
+	if err != nil {
+		t.Fatal("Item trapper hosts error:", err)
+	}
+
+	if len(iObjects) != 1 || iObjects[0].TrapperHosts != "10.1.1.0/24,collector.example.com" {
+		t.Errorf("Item trapper hosts error: unexpected items %v", iObjects)
+	}
+
+	t.Logf("Item trapper hosts: success")
+}
+
+func TestItemEmptyLastValues(t *testing.T) {
+
+	var items []map[string]interface{}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "item.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return items, nil
+	})
+	defer closeMock()
+
+	// Populated values
+	items = []map[string]interface{}{
+		{"itemid": "1001", "lastclock": "1600000000", "lastns": "123", "lastvalue": "5"},
+	}
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{})
+	if err != nil {
+		t.Fatal("Item empty last values error (populated):", err)
+	}
+
+	if len(iObjects) != 1 || iObjects[0].LastClock != 1600000000 || iObjects[0].LastNs != 123 || iObjects[0].LastValue != "5" {
+		t.Errorf("Item empty last values error (populated): unexpected items %v", iObjects)
+	}
+
+	// Missing values
+	items = []map[string]interface{}{
+		{"itemid": "1001", "key_": testItemKey},
+	}
+
+	iObjects, _, err = z.ItemGet(ItemGetParams{})
+	if err != nil {
+		t.Fatal("Item empty last values error (missing):", err)
+	}
+
+	if len(iObjects) != 1 || iObjects[0].LastClock != 0 || iObjects[0].LastValue != "" {
+		t.Errorf("Item empty last values error (missing): unexpected items %v", iObjects)
+	}
+
+	// Empty values
+	items = []map[string]interface{}{
+		{"itemid": "1001", "lastclock": "", "lastns": "", "lastvalue": "", "prevvalue": ""},
+	}
+
+	iObjects, _, err = z.ItemGet(ItemGetParams{})
+	if err != nil {
+		t.Fatal("Item empty last values error (empty):", err)
+	}
+
+	if len(iObjects) != 1 || iObjects[0].ItemID != 1001 || iObjects[0].LastClock != 0 || iObjects[0].LastNs != 0 {
+		t.Errorf("Item empty last values error (empty): unexpected items %v", iObjects)
+	}
+
+	// Malformed values
+	items = []map[string]interface{}{
+		{"itemid": "1001", "lastclock": "abc"},
+	}
+
+	if _, _, err := z.ItemGet(ItemGetParams{}); err == nil {
+		t.Error("Item empty last values error (malformed): expected error")
+	}
+
+	t.Logf("Item empty last values: success")
+}
+
+func testItemCreate(t *testing.T, z Context, hostID int) []int {
+
+	iCreatedIDs, _, err := z.ItemCreate([]ItemCreateParams{
+		{
+			HostID:    hostID,
+			Name:      "Test item",
+			Key:       testItemKey,
+			Type:      ItemTypeZabbixTrapper,
+			ValueType: ItemValueTypeNumericUnsigned,
+		},
+	})
+	if err != nil {
+		t.Fatal("Item create error:", err)
+	}
+
+	if len(iCreatedIDs) == 0 {
+		t.Fatal("Item create error: empty IDs array")
+	}
+
+	t.Logf("Item create: success")
+
+	return iCreatedIDs
+}
+
+func testItemUpdate(t *testing.T, z Context, iCreatedIDs []int) []int {
+
+	var iObjects []ItemObject
+
+	for _, id := range iCreatedIDs {
+		iObjects = append(iObjects, ItemObject{
+			ItemID:  id,
+			History: "7d",
+		})
+	}
+
+	iUpdatedIDs, _, err := z.ItemUpdate(iObjects)
+	if err != nil {
+		t.Fatal("Item update error:", err)
+	}
+
+	if len(iUpdatedIDs) == 0 {
+		t.Fatal("Item update error: empty IDs array")
+	}
+
+	if reflect.DeepEqual(iUpdatedIDs, iCreatedIDs) == false {
+		t.Fatal("Item update error: IDs arrays for created and updated item are mismatch")
+	}
+
+	t.Logf("Item update: success")
+
+	return iUpdatedIDs
+}
+
+func testItemDelete(t *testing.T, z Context, iCreatedIDs []int) []int {
+
+	iDeletedIDs, _, err := z.ItemDelete(iCreatedIDs)
+	if err != nil {
+		t.Fatal("Item delete error:", err)
+	}
+
+	if len(iDeletedIDs) == 0 {
+		t.Fatal("Item delete error: empty IDs array")
+	}
+
+	if reflect.DeepEqual(iDeletedIDs, iCreatedIDs) == false {
+		t.Fatal("Item delete error: IDs arrays for created and deleted item are mismatch")
+	}
+
+	t.Logf("Item delete: success")
+
+	return iDeletedIDs
+}
+
+func TestItemGetCtx(t *testing.T) {
+
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		// Request hangs until the test is finished or client is gone
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	z := Context{
+		host:       srv.URL,
+		sessionKey: "mockSessionKey",
+	}
+
+	// Canceled before request
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, err := z.ItemGetCtx(ctx, ItemGetParams{}); errors.Is(err, context.Canceled) == false {
+		t.Errorf("Item get ctx error (canceled before): expected context error, got %v", err)
+	}
+
+	// Canceled during request
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+
+	_, _, err := z.ItemGetCtx(ctx, ItemGetParams{})
+	if errors.Is(err, context.Canceled) == false {
+		t.Errorf("Item get ctx error (canceled during): expected context error, got %v", err)
+	}
+
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("Item get ctx error (canceled during): request is not aborted promptly (%s)", d)
+	}
+
+	if err != nil && strings.Contains(err.Error(), "item.get") == false {
+		t.Errorf("Item get ctx error: method name is missing in error: %v", err)
+	}
+
+	t.Logf("Item get ctx: success")
+}
+
+func TestItemFields(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "item.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return []map[string]interface{}{
+			{
+				"itemid":     "1001",
+				"key_":       "system.cpu.load[all,avg1]",
+				"type":       "0",
+				"status":     "1",
+				"delay":      "1m",
+				"units":      "%",
+				"valuemapid": "15",
+			},
+			{
+				"itemid": "1002",
+				"key_":   testItemKey,
+			},
+		}, nil
+	})
+	defer closeMock()
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		t.Fatal("Item fields error:", err)
+	}
+
+	expected := []ItemObject{
+		{
+			ItemID:     1001,
+			Key:        "system.cpu.load[all,avg1]",
+			Type:       ItemTypeZabbixAgent,
+			Status:     ItemStatusDisabled,
+			Delay:      "1m",
+			Units:      "%",
+			ValueMapID: 15,
+		},
+		{
+			ItemID: 1002,
+			Key:    testItemKey,
+		},
+	}
+
+	if reflect.DeepEqual(iObjects, expected) == false {
+		t.Errorf("Item fields error: unexpected items %+v", iObjects)
+	}
+
+	t.Logf("Item fields: success")
+}
+
+func TestItemKeyBuild(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		params   []string
+		expected string
+	}{
+		{
+			name:     "agent.ping",
+			expected: "agent.ping",
+		},
+		{
+			name:     "vfs.fs.size",
+			params:   []string{"/", "pfree"},
+			expected: "vfs.fs.size[/,pfree]",
+		},
+		{
+			name:     "custom.key",
+			params:   []string{"a,b", ""},
+			expected: `custom.key["a,b",]`,
+		},
+		{
+			name:     "custom.key",
+			params:   []string{`say "hi"`},
+			expected: `custom.key["say \"hi\""]`,
+		},
+		{
+			name:     "custom.key",
+			params:   []string{"[1]", " x", `C:\Temp`},
+			expected: `custom.key["[1]"," x",C:\Temp]`,
+		},
+	}
+
+	for _, tt := range tests {
+		if k, err := BuildItemKey(tt.name, tt.params...); err != nil || k != tt.expected {
+			t.Errorf("Item key build error: expected %s, got %s (%v)", tt.expected, k, err)
+		}
+	}
+
+	if q, err := QuoteItemKeyParam(`a"b`); err != nil || q != `"a\"b"` {
+		t.Errorf("Item key build error: unexpected quoted param %s (%v)", q, err)
+	}
+
+	// Trailing backslash would escape the closing quote
+	if _, err := QuoteItemKeyParam(`C:\Temp\`); err == nil {
+		t.Error("Item key build error: expected error for param ending with backslash")
+	}
+
+	if _, err := BuildItemKey("custom.key", `a,C:\Temp\`); err == nil {
+		t.Error("Item key build error: expected error for quoted param ending with backslash")
+	}
+
+	// Unquoted param may end with backslash
+	if k, err := BuildItemKey("custom.key", `C:\Temp\`); err != nil || k != `custom.key[C:\Temp\]` {
+		t.Errorf("Item key build error: unexpected key %s (%v)", k, err)
+	}
+
+	t.Logf("Item key build: success")
+}
+
+func TestItemHostName(t *testing.T) {
+
+	var selectHosts json.RawMessage
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p struct {
+			SelectHosts json.RawMessage `json:"selectHosts"`
+		}
+
+		if method != "item.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		selectHosts = p.SelectHosts
+
+		return []map[string]interface{}{
+			{"itemid": "1001", "hosts": []map[string]interface{}{{"hostid": "10084", "host": "web-1", "name": "Web server 1"}}},
+			{"itemid": "1002", "hosts": []map[string]interface{}{{"hostid": "10001", "host": "Template OS Linux", "name": ""}}},
+			{"itemid": "1003"},
+		}, nil
+	})
+	defer closeMock()
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{
+		SelectHosts: SelectFields{"host", "name"},
+	})
+	if err != nil {
+		t.Fatal("Item host name error:", err)
+	}
+
+	if string(selectHosts) != `["host","name"]` {
+		t.Errorf("Item host name error: unexpected selectHosts param %s", string(selectHosts))
+	}
+
+	var names []string
+	for _, i := range iObjects {
+		names = append(names, i.HostName())
+	}
+
+	if reflect.DeepEqual(names, []string{"Web server 1", "Template OS Linux", ""}) == false {
+		t.Errorf("Item host name error: unexpected host names %q", names)
+	}
+
+	t.Logf("Item host name: success")
+}
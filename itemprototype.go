@@ -0,0 +1,170 @@
+package zabbix
+
+import "fmt"
+
+// For `ItemprototypeObject` field: `Status`
+const (
+	ItemprototypeStatusEnabled  = 0
+	ItemprototypeStatusDisabled = 1
+)
+
+// For `ItemprototypeObject` field: `Discover`
+const (
+	ItemprototypeDiscoverTrue  = 0
+	ItemprototypeDiscoverFalse = 1
+)
+
+// ItemprototypeObject struct is used to store item prototype operations results
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/itemprototype/object
+type ItemprototypeObject struct {
+	ItemID      int       `json:"itemid,omitempty"`
+	Delay       string    `json:"delay,omitempty"`
+	HostID      int       `json:"hostid,omitempty"`
+	RuleID      int       `json:"ruleid,omitempty"` // Used for `create` operations
+	InterfaceID int       `json:"interfaceid,omitempty"`
+	Key         string    `json:"key_,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Type        int       `json:"type,omitempty"`
+	ValueType   ValueType `json:"value_type,omitempty"` // see `ItemValueType*` consts
+	Description string    `json:"description,omitempty"`
+	History     string    `json:"history,omitempty"`
+	Trends      string    `json:"trends,omitempty"`
+	Status      int       `json:"status,omitempty"` // has defined consts, see above
+	TemplateID  int       `json:"templateid,omitempty"`
+	Units       string    `json:"units,omitempty"`
+	Discover    int       `json:"discover,omitempty"` // has defined consts, see above
+}
+
+// ItemprototypeGetParams struct is used for item prototype get requests
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/itemprototype/get#parameters
+type ItemprototypeGetParams struct {
+	GetParameters
+
+	DiscoveryIDs []int `json:"discoveryids,omitempty"`
+	GraphIDs     []int `json:"graphids,omitempty"`
+	HostIDs      []int `json:"hostids,omitempty"`
+	Inherited    bool  `json:"inherited,omitempty"`
+	ItemIDs      []int `json:"itemids,omitempty"`
+	Monitored    bool  `json:"monitored,omitempty"`
+	Templated    bool  `json:"templated,omitempty"`
+	TemplateIDs  []int `json:"templateids,omitempty"`
+	TriggerIDs   []int `json:"triggerids,omitempty"`
+
+	// SelectApplications  SelectQuery `json:"selectApplications,omitempty"` // not implemented yet
+	// SelectDiscoveryRule SelectQuery `json:"selectDiscoveryRule,omitempty"` // not implemented yet
+	// SelectGraphs        SelectQuery `json:"selectGraphs,omitempty"` // not implemented yet
+	// SelectHosts         SelectQuery `json:"selectHosts,omitempty"` // not implemented yet
+	// SelectTriggers      SelectQuery `json:"selectTriggers,omitempty"` // not implemented yet
+	// SelectPreprocessing SelectQuery `json:"selectPreprocessing,omitempty"` // not implemented yet
+}
+
+// ItemprototypeMassUpdate struct is used to specify changes for `MassUpdateItemPrototypes`.
+// Only not nil fields are updated
+type ItemprototypeMassUpdate struct {
+	Delay       *string
+	Description *string
+	History     *string
+	Trends      *string
+	Status      *int // has defined consts, see above
+	Units       *string
+}
+
+// Structure to store updation result
+type itemprototypeUpdateResult struct {
+	ItemIDs []int `json:"itemids"`
+}
+
+// ItemprototypeGet gets item prototypes
+func (z *Context) ItemprototypeGet(params ItemprototypeGetParams) ([]ItemprototypeObject, int, error) {
+
+	var result []ItemprototypeObject
+
+	status, err := z.request("itemprototype.get", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result, status, nil
+}
+
+// ItemprototypeUpdate updates item prototypes
+func (z *Context) ItemprototypeUpdate(params []ItemprototypeObject) ([]int, int, error) {
+
+	var result itemprototypeUpdateResult
+
+	status, err := z.request("itemprototype.update", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.ItemIDs, status, nil
+}
+
+// MassUpdateItemPrototypes applies `changes` to all item prototypes of the LLD rule with ID `ruleID`
+// and returns IDs of the updated item prototypes
+func (z *Context) MassUpdateItemPrototypes(ruleID int, changes ItemprototypeMassUpdate) ([]int, error) {
+
+	var result itemprototypeUpdateResult
+
+	c := make(map[string]interface{})
+
+	if changes.Delay != nil {
+		c["delay"] = *changes.Delay
+	}
+	if changes.Description != nil {
+		c["description"] = *changes.Description
+	}
+	if changes.History != nil {
+		c["history"] = *changes.History
+	}
+	if changes.Trends != nil {
+		c["trends"] = *changes.Trends
+	}
+	if changes.Status != nil {
+		c["status"] = *changes.Status
+	}
+	if changes.Units != nil {
+		c["units"] = *changes.Units
+	}
+
+	if len(c) == 0 {
+		return nil, fmt.Errorf("no changes specified")
+	}
+
+	ipObjects, _, err := z.ItemprototypeGet(ItemprototypeGetParams{
+		DiscoveryIDs: []int{ruleID},
+		GetParameters: GetParameters{
+			Output: SelectFields{"itemid"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ipObjects) == 0 {
+		return []int{}, nil
+	}
+
+	var params []map[string]interface{}
+
+	for _, ip := range ipObjects {
+
+		p := map[string]interface{}{
+			"itemid": ip.ItemID,
+		}
+
+		for k, v := range c {
+			p[k] = v
+		}
+
+		params = append(params, p)
+	}
+
+	if _, err := z.request("itemprototype.update", params, &result); err != nil {
+		return nil, err
+	}
+
+	return result.ItemIDs, nil
+}
@@ -0,0 +1,81 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+const (
+	testItemprototypeRuleID  = 500
+	testItemprototypeHistory = "7d"
+)
+
+func TestItemprototypeMassUpdate(t *testing.T) {
+
+	var updated []map[string]interface{}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "itemprototype.get":
+
+			var p ItemprototypeGetParams
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			if reflect.DeepEqual(p.DiscoveryIDs, []int{testItemprototypeRuleID}) == false {
+				return nil, fmt.Errorf("unexpected discoveryids %v", p.DiscoveryIDs)
+			}
+
+			return []map[string]interface{}{
+				{"itemid": "601"},
+				{"itemid": "602"},
+			}, nil
+
+		case "itemprototype.update":
+
+			if err := json.Unmarshal(params, &updated); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{
+				"itemids": []string{"601", "602"},
+			}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	history := testItemprototypeHistory
+
+	ipUpdatedIDs, err := z.MassUpdateItemPrototypes(testItemprototypeRuleID, ItemprototypeMassUpdate{
+		History: &history,
+	})
+	if err != nil {
+		t.Fatal("Item prototype mass update error:", err)
+	}
+
+	if reflect.DeepEqual(ipUpdatedIDs, []int{601, 602}) == false {
+		t.Errorf("Item prototype mass update error: unexpected IDs %v", ipUpdatedIDs)
+	}
+
+	expected := []map[string]interface{}{
+		{"itemid": float64(601), "history": testItemprototypeHistory},
+		{"itemid": float64(602), "history": testItemprototypeHistory},
+	}
+
+	if reflect.DeepEqual(updated, expected) == false {
+		t.Errorf("Item prototype mass update error: unexpected payload %v", updated)
+	}
+
+	// Empty changes
+	if _, err := z.MassUpdateItemPrototypes(testItemprototypeRuleID, ItemprototypeMassUpdate{}); err == nil {
+		t.Error("Item prototype mass update error: empty changes accepted")
+	}
+
+	t.Logf("Item prototype mass update: success")
+}
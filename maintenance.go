@@ -0,0 +1,179 @@
+package zabbix
+
+import (
+	"fmt"
+	"time"
+)
+
+// For `MaintenanceObject` field: `MaintenanceType`
+const (
+	MaintenanceTypeWithDataCollection    = 0
+	MaintenanceTypeWithoutDataCollection = 1
+)
+
+// For `MaintenanceTimeperiodObject` field: `TimeperiodType`
+const (
+	MaintenanceTimeperiodTypeOneTime = 0
+	MaintenanceTimeperiodTypeDaily   = 2
+	MaintenanceTimeperiodTypeWeekly  = 3
+	MaintenanceTimeperiodTypeMonthly = 4
+)
+
+// Zabbix API version maintenance hosts and groups are set with objects instead of IDs since
+const maintenanceObjectsVersion = "6.0"
+
+// Min maintenance time period duration allowed by Zabbix
+const maintenancePeriodMin = 5 * time.Minute
+
+// MaintenanceObject struct is used to store maintenance operations results
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/object#maintenance
+type MaintenanceObject struct {
+	MaintenanceID   int    `json:"maintenanceid,omitempty"`
+	Name            string `json:"name,omitempty"`
+	ActiveSince     int    `json:"active_since,omitempty"`
+	ActiveTill      int    `json:"active_till,omitempty"`
+	Description     string `json:"description,omitempty"`
+	MaintenanceType int    `json:"maintenance_type,omitempty"` // has defined consts, see above
+
+	Groups      []HostgroupObject             `json:"groups,omitempty"`
+	Hosts       []HostObject                  `json:"hosts,omitempty"`
+	Timeperiods []MaintenanceTimeperiodObject `json:"timeperiods,omitempty"`
+}
+
+// MaintenanceTimeperiodObject struct is used to store maintenance time period
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/object#time_period
+type MaintenanceTimeperiodObject struct {
+	TimeperiodType int `json:"timeperiod_type"` // has defined consts, see above
+	StartDate      int `json:"start_date,omitempty"`
+	Period         int `json:"period,omitempty"`
+	StartTime      int `json:"start_time,omitempty"`
+	Every          int `json:"every,omitempty"`
+	DayOfWeek      int `json:"dayofweek,omitempty"`
+	Day            int `json:"day,omitempty"`
+	Month          int `json:"month,omitempty"`
+}
+
+// MaintenanceGetParams struct is used for maintenance get requests
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/get#parameters
+type MaintenanceGetParams struct {
+	GetParameters
+
+	GroupIDs       []int `json:"groupids,omitempty"`
+	HostIDs        []int `json:"hostids,omitempty"`
+	MaintenanceIDs []int `json:"maintenanceids,omitempty"`
+
+	SelectGroups      SelectQuery `json:"selectGroups,omitempty"`
+	SelectHosts       SelectQuery `json:"selectHosts,omitempty"`
+	SelectTimeperiods SelectQuery `json:"selectTimeperiods,omitempty"`
+}
+
+// Structure to store creation result
+type maintenanceCreateResult struct {
+	MaintenanceIDs []int `json:"maintenanceids"`
+}
+
+// Structure to store deletion result
+type maintenanceDeleteResult struct {
+	MaintenanceIDs []int `json:"maintenanceids"`
+}
+
+// MaintenanceGet gets maintenances
+func (z *Context) MaintenanceGet(params MaintenanceGetParams) ([]MaintenanceObject, int, error) {
+
+	var result []MaintenanceObject
+
+	status, err := z.request("maintenance.get", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result, status, nil
+}
+
+// MaintenanceDelete deletes maintenances
+func (z *Context) MaintenanceDelete(maintenanceIDs []int) ([]int, int, error) {
+
+	var result maintenanceDeleteResult
+
+	status, err := z.request("maintenance.delete", maintenanceIDs, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.MaintenanceIDs, status, nil
+}
+
+// ScheduleMaintenance creates maintenance for the hosts and host groups with a single one-time period
+// starting at `start` and lasting `dur` (at least 5 minutes), the maintenance is active within the
+// same period. Data is collected during the maintenance if `dataCollection` is set.
+// Returns ID of the created maintenance
+func (z *Context) ScheduleMaintenance(name string, hostIDs, groupIDs []int, start time.Time, dur time.Duration, dataCollection bool) (int, error) {
+
+	var result maintenanceCreateResult
+
+	if len(hostIDs) == 0 && len(groupIDs) == 0 {
+		return 0, fmt.Errorf("maintenance must contain at least one host or host group")
+	}
+
+	if dur < maintenancePeriodMin {
+		return 0, fmt.Errorf("maintenance duration %s is too short, must be at least %s", dur, maintenancePeriodMin)
+	}
+
+	objects, err := z.apiVersionAtLeast(maintenanceObjectsVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	maintenanceType := MaintenanceTypeWithoutDataCollection
+	if dataCollection == true {
+		maintenanceType = MaintenanceTypeWithDataCollection
+	}
+
+	// Map is used instead of `MaintenanceObject` to send zero maintenance type
+	// and hosts and groups in the format of the server version
+	params := map[string]interface{}{
+		"name":             name,
+		"active_since":     start.Unix(),
+		"active_till":      start.Add(dur).Unix(),
+		"maintenance_type": maintenanceType,
+		"timeperiods": []MaintenanceTimeperiodObject{
+			{
+				TimeperiodType: MaintenanceTimeperiodTypeOneTime,
+				StartDate:      int(start.Unix()),
+				Period:         int(dur / time.Second),
+			},
+		},
+	}
+
+	if objects == true {
+
+		hosts := []map[string]interface{}{}
+		for _, id := range hostIDs {
+			hosts = append(hosts, map[string]interface{}{"hostid": id})
+		}
+
+		groups := []map[string]interface{}{}
+		for _, id := range groupIDs {
+			groups = append(groups, map[string]interface{}{"groupid": id})
+		}
+
+		params["hosts"] = hosts
+		params["groups"] = groups
+	} else {
+		params["hostids"] = append([]int{}, hostIDs...)
+		params["groupids"] = append([]int{}, groupIDs...)
+	}
+
+	if _, err := z.request("maintenance.create", params, &result); err != nil {
+		return 0, fmt.Errorf("maintenance create error: %v", err)
+	}
+
+	if len(result.MaintenanceIDs) == 0 {
+		return 0, fmt.Errorf("maintenance create error: empty IDs array")
+	}
+
+	return result.MaintenanceIDs[0], nil
+}
@@ -0,0 +1,98 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceSchedule(t *testing.T) {
+
+	start := time.Date(2020, 6, 1, 22, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		version  string
+		expected map[string]interface{}
+	}{
+		{
+			version: "5.0.2",
+			expected: map[string]interface{}{
+				"name":             "Network upgrade",
+				"active_since":     float64(1591048800),
+				"active_till":      float64(1591056000),
+				"maintenance_type": float64(MaintenanceTypeWithDataCollection),
+				"timeperiods": []interface{}{
+					map[string]interface{}{"timeperiod_type": float64(0), "start_date": float64(1591048800), "period": float64(7200)},
+				},
+				"hostids":  []interface{}{float64(10084)},
+				"groupids": []interface{}{float64(2)},
+			},
+		},
+		{
+			version: "6.0.0",
+			expected: map[string]interface{}{
+				"name":             "Network upgrade",
+				"active_since":     float64(1591048800),
+				"active_till":      float64(1591056000),
+				"maintenance_type": float64(MaintenanceTypeWithDataCollection),
+				"timeperiods": []interface{}{
+					map[string]interface{}{"timeperiod_type": float64(0), "start_date": float64(1591048800), "period": float64(7200)},
+				},
+				"hosts":  []interface{}{map[string]interface{}{"hostid": float64(10084)}},
+				"groups": []interface{}{map[string]interface{}{"groupid": float64(2)}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+
+		var created map[string]interface{}
+
+		z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+			switch method {
+			case "apiinfo.version":
+				return tt.version, nil
+			case "maintenance.create":
+
+				if err := json.Unmarshal(params, &created); err != nil {
+					return nil, err
+				}
+
+				return map[string]interface{}{"maintenanceids": []string{"3"}}, nil
+			}
+
+			return nil, fmt.Errorf("unexpected method %s", method)
+		})
+
+		id, err := z.ScheduleMaintenance("Network upgrade", []int{10084}, []int{2}, start, 2*time.Hour, true)
+		closeMock()
+
+		if err != nil {
+			t.Fatalf("Maintenance schedule error (%s): %v", tt.version, err)
+		}
+
+		if id != 3 {
+			t.Errorf("Maintenance schedule error (%s): unexpected ID %d", tt.version, id)
+		}
+
+		if reflect.DeepEqual(created, tt.expected) == false {
+			t.Errorf("Maintenance schedule error (%s): unexpected params %v", tt.version, created)
+		}
+	}
+
+	// Validation
+	z := Context{}
+
+	if _, err := z.ScheduleMaintenance("Empty", nil, nil, start, 2*time.Hour, true); err == nil {
+		t.Error("Maintenance schedule error: expected error for maintenance without hosts and groups")
+	}
+
+	if _, err := z.ScheduleMaintenance("Short", []int{10084}, nil, start, time.Minute, true); err == nil {
+		t.Error("Maintenance schedule error: expected error for too short maintenance")
+	}
+
+	t.Logf("Maintenance schedule: success")
+}
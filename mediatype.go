@@ -36,7 +36,9 @@ const (
 // For `MediatypeObject` field: `Status`
 const (
 	MediatypeStatusEnabled  = 0
-	MediatypeScriptDisabled = 1
+	MediatypeStatusDisabled = 1
+
+	MediatypeScriptDisabled = MediatypeStatusDisabled // Deprecated: use `MediatypeStatusDisabled`
 )
 
 // For `MediatypeObject` field: `ContentType`
@@ -147,6 +149,11 @@ type mediatypeCreateResult struct {
 	MediatypeIDs []int `json:"mediatypeids"`
 }
 
+// Structure to store updation result
+type mediatypeUpdateResult struct {
+	MediatypeIDs []int `json:"mediatypeids"`
+}
+
 // Structure to store deletion result
 type mediatypeDeleteResult struct {
 	MediatypeIDs []int `json:"mediatypeids"`
@@ -190,3 +197,30 @@ func (z *Context) MediatypeDelete(mediatypeIDs []int) ([]int, int, error) {
 
 	return result.MediatypeIDs, status, nil
 }
+
+// EnableMediaType enables the mediatype
+func (z *Context) EnableMediaType(id int) error {
+
+	return z.mediatypeStatusSet(id, MediatypeStatusEnabled)
+}
+
+// DisableMediaType disables the mediatype, e.g. to silence notification channel during maintenance
+func (z *Context) DisableMediaType(id int) error {
+
+	return z.mediatypeStatusSet(id, MediatypeStatusDisabled)
+}
+
+func (z *Context) mediatypeStatusSet(id, status int) error {
+
+	var result mediatypeUpdateResult
+
+	// Map is used to be able to send zero status (`MediatypeStatusEnabled`)
+	if _, err := z.request("mediatype.update", map[string]interface{}{
+		"mediatypeid": id,
+		"status":      status,
+	}, &result); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package zabbix
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 )
@@ -105,3 +107,53 @@ func testMediatypeGet(t *testing.T, z Context, mtCreatedIDs []int) []MediatypeOb
 
 	return mtObjects
 }
+
+func TestMediatypeStatusToggle(t *testing.T) {
+
+	var sent map[string]interface{}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "mediatype.update" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		sent = nil
+		if err := json.Unmarshal(params, &sent); err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{"mediatypeids": []string{"4"}}, nil
+	})
+	defer closeMock()
+
+	tests := []struct {
+		name   string
+		toggle func(int) error
+		status int
+	}{
+		{
+			name:   "enable",
+			toggle: z.EnableMediaType,
+			status: MediatypeStatusEnabled,
+		},
+		{
+			name:   "disable",
+			toggle: z.DisableMediaType,
+			status: MediatypeStatusDisabled,
+		},
+	}
+
+	for _, tt := range tests {
+
+		if err := tt.toggle(4); err != nil {
+			t.Fatalf("Mediatype status toggle error (%s): %v", tt.name, err)
+		}
+
+		if sent["mediatypeid"] != float64(4) || sent["status"] != float64(tt.status) {
+			t.Errorf("Mediatype status toggle error (%s): unexpected params %v", tt.name, sent)
+		}
+	}
+
+	t.Logf("Mediatype status toggle: success")
+}
@@ -0,0 +1,556 @@
+package zabbix
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Max number of events closed by single `event.acknowledge` request in `CloseGroupProblems`
+const problemCloseBatchSize = 100
+
+// For `ProblemObject` field: `Source`
+const (
+	ProblemSourceTrigger  = 0
+	ProblemSourceInternal = 3
+)
+
+// For `ProblemObject` field: `Object`
+const (
+	ProblemObjectTrigger = 0
+	ProblemObjectItem    = 4
+	ProblemObjectLLDRule = 5
+)
+
+// For `ProblemObject` field: `Acknowledged`
+const (
+	ProblemAcknowledgedFalse = 0
+	ProblemAcknowledgedTrue  = 1
+)
+
+// For `ProblemObject` field: `Severity`
+const (
+	ProblemSeverityNotClassified = 0
+	ProblemSeverityInformation   = 1
+	ProblemSeverityWarning       = 2
+	ProblemSeverityAverage       = 3
+	ProblemSeverityHigh          = 4
+	ProblemSeverityDisaster      = 5
+)
+
+// For `ProblemObject` field: `Suppressed`
+const (
+	ProblemSuppressedFalse = 0
+	ProblemSuppressedTrue  = 1
+)
+
+// For `ProblemAcknowledgeObject` field: `Action` (bitmask)
+const (
+	ProblemAcknowledgeActionClose          = 1
+	ProblemAcknowledgeActionAcknowledge    = 2
+	ProblemAcknowledgeActionMessage        = 4
+	ProblemAcknowledgeActionChangeSeverity = 8
+)
+
+// For `ProblemGetParams` field: `Evaltype`
+const (
+	ProblemEvaltypeAndOr = 0
+	ProblemEvaltypeOr    = 2
+)
+
+// For `ProblemTagObject` field: `Operator`
+const (
+	ProblemTagOperatorContains = 0
+	ProblemTagOperatorEquals   = 1
+)
+
+// Zabbix API version problems operational data is introduced in
+const problemOpDataVersion = "5.0"
+
+// User macro reference within operational data, e.g. `{$THRESHOLD}` or `{$THRESHOLD:"context"}`
+var problemOpDataMacroRegexp = regexp.MustCompile(`\{\$[A-Z0-9_.]+(?::[^}]*)?\}`)
+
+// ProblemObject struct is used to store problem operations results
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/problem/object#problem
+type ProblemObject struct {
+	EventID       int    `json:"eventid,omitempty"`
+	Source        int    `json:"source,omitempty"` // has defined consts, see above
+	Object        int    `json:"object,omitempty"` // has defined consts, see above
+	ObjectID      int    `json:"objectid,omitempty"`
+	Clock         int    `json:"clock,omitempty"`
+	NS            int    `json:"ns,omitempty"`
+	REventID      int    `json:"r_eventid,omitempty"`
+	RClock        int    `json:"r_clock,omitempty"`
+	RNS           int    `json:"r_ns,omitempty"`
+	CorrelationID int    `json:"correlationid,omitempty"`
+	UserID        int    `json:"userid,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Acknowledged  int    `json:"acknowledged,omitempty"` // has defined consts, see above
+	Severity      int    `json:"severity,omitempty"`     // has defined consts, see above
+	Suppressed    int    `json:"suppressed,omitempty"`   // has defined consts, see above
+	OpData        string `json:"opdata,omitempty"`       // since Zabbix 5.0
+
+	Acknowledges []ProblemAcknowledgeObject `json:"acknowledges,omitempty"`
+	Tags         []ProblemTagObject         `json:"tags,omitempty"`
+}
+
+// ProblemAcknowledgeObject struct is used to store problem acknowledges
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/problem/get#returned_values
+type ProblemAcknowledgeObject struct {
+	AcknowledgeID int    `json:"acknowledgeid,omitempty"`
+	UserID        int    `json:"userid,omitempty"`
+	EventID       int    `json:"eventid,omitempty"`
+	Clock         int    `json:"clock,omitempty"`
+	Message       string `json:"message,omitempty"`
+	Action        int    `json:"action,omitempty"` // has defined consts, see above
+	OldSeverity   int    `json:"old_severity,omitempty"`
+	NewSeverity   int    `json:"new_severity,omitempty"`
+}
+
+// ProblemTagObject struct is used to store problem tag
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/problem/object#problem_tag
+type ProblemTagObject struct {
+	Tag   string `json:"tag,omitempty"`
+	Value string `json:"value,omitempty"`
+
+	Operator int `json:"operator,omitempty"` // Used for `get` operations, has defined consts, see above
+}
+
+// ProblemGetParams struct is used for problem get requests
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/problem/get#parameters
+type ProblemGetParams struct {
+	GetParameters
+
+	EventIDs       []int              `json:"eventids,omitempty"`
+	GroupIDs       []int              `json:"groupids,omitempty"`
+	HostIDs        []int              `json:"hostids,omitempty"`
+	ObjectIDs      []int              `json:"objectids,omitempty"`
+	ApplicationIDs []int              `json:"applicationids,omitempty"`
+	Source         int                `json:"source,omitempty"` // has defined consts, see above
+	Object         int                `json:"object,omitempty"` // has defined consts, see above
+	Acknowledged   bool               `json:"acknowledged,omitempty"`
+	Suppressed     bool               `json:"suppressed,omitempty"`
+	Severities     []int              `json:"severities,omitempty"`
+	Evaltype       int                `json:"evaltype,omitempty"` // has defined consts, see above
+	Tags           []ProblemTagObject `json:"tags,omitempty"`
+	Recent         bool               `json:"recent,omitempty"`
+	EventIDFrom    int                `json:"eventid_from,omitempty"`
+	EventIDTill    int                `json:"eventid_till,omitempty"`
+	TimeFrom       int                `json:"time_from,omitempty"`
+	TimeTill       int                `json:"time_till,omitempty"`
+
+	SelectAcknowledges SelectQuery `json:"selectAcknowledges,omitempty"`
+	SelectTags         SelectQuery `json:"selectTags,omitempty"`
+	// SelectSuppressionData SelectQuery `json:"selectSuppressionData,omitempty"` // not implemented yet
+}
+
+// IsAcknowledged checks the problem is acknowledged.
+// Note that `Acknowledges` may contain records which do not acknowledge the problem (e.g. messages only)
+func (p *ProblemObject) IsAcknowledged() bool {
+
+	return p.Acknowledged == ProblemAcknowledgedTrue
+}
+
+// ProblemGet gets problems
+func (z *Context) ProblemGet(params ProblemGetParams) ([]ProblemObject, int, error) {
+
+	var result []ProblemObject
+
+	status, err := z.request("problem.get", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result, status, nil
+}
+
+// GetProblemsWithOpData gets problems with operational data (`OpData` field). `opdata` field is
+// added to the requested output if it is limited to a list of fields
+func (z *Context) GetProblemsWithOpData(params ProblemGetParams) ([]ProblemObject, error) {
+
+	if err := z.apiVersionRequire(problemOpDataVersion, "problems operational data"); err != nil {
+		return nil, err
+	}
+
+	switch o := params.Output.(type) {
+	case nil:
+		params.Output = SelectExtendedOutput
+	case SelectFields:
+		params.Output = append(append(SelectFields{}, o...), "opdata")
+	}
+
+	pObjects, _, err := z.ProblemGet(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return pObjects, nil
+}
+
+// ResolveOpDataMacros resolves user macros left in operational data of the host's problem
+// (see `ResolveHostMacro()`). An error is returned if some macro can not be resolved
+func (z *Context) ResolveOpDataMacros(hostID int, opdata string) (string, error) {
+
+	var err error
+
+	values := make(map[string]string)
+
+	r := problemOpDataMacroRegexp.ReplaceAllStringFunc(opdata, func(m string) string {
+
+		if err != nil {
+			return m
+		}
+
+		if v, ok := values[m]; ok == true {
+			return v
+		}
+
+		v, _, e := z.ResolveHostMacro(hostID, m)
+		if e != nil {
+			err = e
+			return m
+		}
+
+		values[m] = v
+
+		return v
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return r, nil
+}
+
+// FetchRecoveryEvents gets recovery events for the resolved problems within a single `event.get` call.
+// Problems without recovery event (`r_eventid` is zero) are skipped.
+// Result is a map of recovery event ID to event.
+func (z *Context) FetchRecoveryEvents(problems []ProblemObject) (map[int]EventObject, error) {
+
+	var eventIDs []int
+
+	r := make(map[int]EventObject)
+
+	for _, p := range problems {
+		if p.REventID != 0 {
+			eventIDs = append(eventIDs, p.REventID)
+		}
+	}
+
+	if len(eventIDs) == 0 {
+		return r, nil
+	}
+
+	eObjects, _, err := z.EventGet(EventGetParams{
+		EventIDs: eventIDs,
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range eObjects {
+		r[e.EventID] = e
+	}
+
+	return r, nil
+}
+
+// GetGroupSeverityCounts counts problems of each severity for the host groups.
+// Problems are bound to groups through their triggers, so the problem is counted for each
+// of specified groups its trigger belongs to.
+// Result is a map of group ID to map of severity (see `ProblemSeverity*` consts) to problems count,
+// all specified groups and severities are present in result
+func (z *Context) GetGroupSeverityCounts(groupIDs []int) (map[int]map[int]int, error) {
+
+	r := make(map[int]map[int]int)
+
+	for _, g := range groupIDs {
+		r[g] = make(map[int]int)
+		for s := ProblemSeverityNotClassified; s <= ProblemSeverityDisaster; s++ {
+			r[g][s] = 0
+		}
+	}
+
+	if len(groupIDs) == 0 {
+		return r, nil
+	}
+
+	pObjects, _, err := z.ProblemGet(ProblemGetParams{
+		GroupIDs: groupIDs,
+		Source:   ProblemSourceTrigger,
+		Object:   ProblemObjectTrigger,
+		GetParameters: GetParameters{
+			Output: SelectFields{"eventid", "objectid", "severity"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get problems error: %v", err)
+	}
+
+	var triggerIDs []int
+
+	for _, p := range pObjects {
+		if containsInt(triggerIDs, p.ObjectID) == false {
+			triggerIDs = append(triggerIDs, p.ObjectID)
+		}
+	}
+
+	if len(triggerIDs) == 0 {
+		return r, nil
+	}
+
+	tObjects, _, err := z.TriggerGet(TriggerGetParams{
+		TriggerIDs:   triggerIDs,
+		SelectGroups: SelectFields{"groupid"},
+		GetParameters: GetParameters{
+			Output: SelectFields{"triggerid"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get triggers error: %v", err)
+	}
+
+	triggerGroups := make(map[int][]int)
+
+	for _, t := range tObjects {
+		for _, g := range t.Groups {
+			triggerGroups[t.TriggerID] = append(triggerGroups[t.TriggerID], g.GroupID)
+		}
+	}
+
+	for _, p := range pObjects {
+		for _, g := range triggerGroups[p.ObjectID] {
+			if _, b := r[g]; b == true {
+				r[g][p.Severity]++
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// IndexProblemsByTag gets problems of the host groups and groups them by the value of the `tag`.
+// Problems without the tag are placed under an empty string key. If the problem has several
+// tags with the same name, it is placed under each of their values
+func (z *Context) IndexProblemsByTag(groupIDs []int, tag string) (map[string][]ProblemObject, error) {
+
+	pObjects, _, err := z.ProblemGet(ProblemGetParams{
+		GroupIDs:   groupIDs,
+		SelectTags: SelectExtendedOutput,
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := make(map[string][]ProblemObject)
+
+	for _, p := range pObjects {
+
+		var values []string
+
+		for _, t := range p.Tags {
+			if t.Tag == tag {
+				values = append(values, t.Value)
+			}
+		}
+
+		if len(values) == 0 {
+			values = []string{""}
+		}
+
+		for _, v := range values {
+			r[v] = append(r[v], p)
+		}
+	}
+
+	return r, nil
+}
+
+// GetProblemsAtLeast gets problems of the host groups with severity not lower than `minSeverity`
+// (see `ProblemSeverity*` consts). If `groupIDs` is empty, problems of all host groups are returned
+func (z *Context) GetProblemsAtLeast(minSeverity int, groupIDs []int) ([]ProblemObject, error) {
+
+	if minSeverity < ProblemSeverityNotClassified || minSeverity > ProblemSeverityDisaster {
+		return nil, fmt.Errorf("invalid severity %d, must be from %d to %d", minSeverity, ProblemSeverityNotClassified, ProblemSeverityDisaster)
+	}
+
+	// `problem.get` accepts a list of severities only
+	severities := []int{}
+	for s := minSeverity; s <= ProblemSeverityDisaster; s++ {
+		severities = append(severities, s)
+	}
+
+	pObjects, _, err := z.ProblemGet(ProblemGetParams{
+		GroupIDs:   groupIDs,
+		Severities: severities,
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pObjects, nil
+}
+
+// ResolveProblemNames returns names of the problems. Problem name (generated from trigger's event name)
+// is used if it is set, otherwise description of the problem's trigger is requested within a single
+// `trigger.get` call. Result is a map of problem event ID to name
+func (z *Context) ResolveProblemNames(problems []ProblemObject) (map[int]string, error) {
+
+	var triggerIDs []int
+
+	r := make(map[int]string)
+
+	for _, p := range problems {
+		if p.Name != "" {
+			r[p.EventID] = p.Name
+			continue
+		}
+		if p.Source == ProblemSourceTrigger && p.Object == ProblemObjectTrigger && containsInt(triggerIDs, p.ObjectID) == false {
+			triggerIDs = append(triggerIDs, p.ObjectID)
+		}
+	}
+
+	if len(triggerIDs) == 0 {
+		return r, nil
+	}
+
+	tObjects, _, err := z.TriggerGet(TriggerGetParams{
+		TriggerIDs: triggerIDs,
+		GetParameters: GetParameters{
+			Output: SelectFields{"triggerid", "description"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get triggers error: %v", err)
+	}
+
+	descriptions := make(map[int]string)
+	for _, t := range tObjects {
+		descriptions[t.TriggerID] = t.Description
+	}
+
+	for _, p := range problems {
+		if p.Name == "" && p.Source == ProblemSourceTrigger && p.Object == ProblemObjectTrigger {
+			r[p.EventID] = descriptions[p.ObjectID]
+		}
+	}
+
+	return r, nil
+}
+
+// ProblemsNotClosableError is returned by `CloseGroupProblems` if some problems were skipped
+// because their triggers do not allow manual close. `EventIDs` contains IDs of skipped problems
+type ProblemsNotClosableError struct {
+	EventIDs []int
+}
+
+func (e *ProblemsNotClosableError) Error() string {
+
+	var s []string
+	for _, id := range e.EventIDs {
+		s = append(s, strconv.Itoa(id))
+	}
+
+	return "problems can not be closed manually: " + strings.Join(s, ", ")
+}
+
+// CloseGroupProblems closes active problems of the host group with the `message`. Problems
+// are closed in batches of `problemCloseBatchSize` events, result contains IDs of closed problems.
+// Problems whose triggers do not allow manual close are skipped, in this case closed IDs are
+// returned along with `*ProblemsNotClosableError`
+func (z *Context) CloseGroupProblems(groupID int, message string) ([]int, error) {
+
+	pObjects, _, err := z.ProblemGet(ProblemGetParams{
+		GroupIDs: []int{groupID},
+		Source:   ProblemSourceTrigger,
+		Object:   ProblemObjectTrigger,
+		GetParameters: GetParameters{
+			Output: SelectFields{"eventid", "objectid"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get problems error: %v", err)
+	}
+
+	if len(pObjects) == 0 {
+		return []int{}, nil
+	}
+
+	var triggerIDs []int
+
+	for _, p := range pObjects {
+		if containsInt(triggerIDs, p.ObjectID) == false {
+			triggerIDs = append(triggerIDs, p.ObjectID)
+		}
+	}
+
+	tObjects, _, err := z.TriggerGet(TriggerGetParams{
+		TriggerIDs: triggerIDs,
+		GetParameters: GetParameters{
+			Output: SelectFields{"triggerid", "manual_close"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get triggers error: %v", err)
+	}
+
+	closable := make(map[int]bool)
+
+	for _, t := range tObjects {
+		closable[t.TriggerID] = t.ManualClose == TriggerManualCloseAllowed
+	}
+
+	var (
+		eventIDs []int
+		skipped  []int
+	)
+
+	for _, p := range pObjects {
+		if closable[p.ObjectID] == true {
+			eventIDs = append(eventIDs, p.EventID)
+		} else {
+			skipped = append(skipped, p.EventID)
+		}
+	}
+
+	closed := []int{}
+
+	for i := 0; i < len(eventIDs); i += problemCloseBatchSize {
+
+		j := i + problemCloseBatchSize
+		if j > len(eventIDs) {
+			j = len(eventIDs)
+		}
+
+		ids, _, err := z.EventAcknowledge(EventAcknowledgeParams{
+			EventIDs: eventIDs[i:j],
+			Action:   EventAcknowledgeActionClose | EventAcknowledgeActionMessage,
+			Message:  message,
+		})
+		if err != nil {
+			return closed, fmt.Errorf("close problems error: %v", err)
+		}
+
+		closed = append(closed, ids...)
+	}
+
+	if len(skipped) > 0 {
+		return closed, &ProblemsNotClosableError{EventIDs: skipped}
+	}
+
+	return closed, nil
+}
@@ -0,0 +1,441 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestProblemFetchRecoveryEvents(t *testing.T) {
+
+	var calls int
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p EventGetParams
+
+		if method != "event.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		calls++
+
+		if reflect.DeepEqual(p.EventIDs, []int{11, 12, 13}) == false {
+			return nil, fmt.Errorf("unexpected eventids %v", p.EventIDs)
+		}
+
+		return []map[string]interface{}{
+			{"eventid": "11", "value": "0", "clock": "1100"},
+			{"eventid": "12", "value": "0", "clock": "1200"},
+			{"eventid": "13", "value": "0", "clock": "1300"},
+		}, nil
+	})
+	defer closeMock()
+
+	events, err := z.FetchRecoveryEvents([]ProblemObject{
+		{EventID: 1, REventID: 11},
+		{EventID: 2, REventID: 12},
+		{EventID: 3},
+		{EventID: 4, REventID: 13},
+	})
+	if err != nil {
+		t.Fatal("Problem fetch recovery events error:", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("Problem fetch recovery events error: expected 1 call, got %d", calls)
+	}
+
+	if len(events) != 3 || events[12].Clock != 1200 {
+		t.Errorf("Problem fetch recovery events error: unexpected result %v", events)
+	}
+
+	t.Logf("Problem fetch recovery events: success")
+}
+
+func TestProblemGroupSeverityCounts(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "problem.get":
+			return []map[string]interface{}{
+				{"eventid": "1", "objectid": "101", "severity": "4"},
+				{"eventid": "2", "objectid": "101", "severity": "4"},
+				{"eventid": "3", "objectid": "102", "severity": "2"},
+				{"eventid": "4", "objectid": "103", "severity": "5"},
+			}, nil
+		case "trigger.get":
+			return []map[string]interface{}{
+				{"triggerid": "101", "groups": []map[string]interface{}{{"groupid": "1"}}},
+				{"triggerid": "102", "groups": []map[string]interface{}{{"groupid": "1"}, {"groupid": "2"}}},
+				{"triggerid": "103", "groups": []map[string]interface{}{{"groupid": "3"}}},
+			}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	counts, err := z.GetGroupSeverityCounts([]int{1, 2, 4})
+	if err != nil {
+		t.Fatal("Problem group severity counts error:", err)
+	}
+
+	expected := map[int]map[int]int{
+		1: {0: 0, 1: 0, 2: 1, 3: 0, 4: 2, 5: 0},
+		2: {0: 0, 1: 0, 2: 1, 3: 0, 4: 0, 5: 0},
+		4: {0: 0, 1: 0, 2: 0, 3: 0, 4: 0, 5: 0},
+	}
+
+	if reflect.DeepEqual(counts, expected) == false {
+		t.Errorf("Problem group severity counts error: unexpected result %v", counts)
+	}
+
+	t.Logf("Problem group severity counts: success")
+}
+
+func TestProblemAcknowledges(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p ProblemGetParams
+
+		if method != "problem.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		if p.SelectAcknowledges != SelectExtendedOutput {
+			return nil, fmt.Errorf("unexpected selectAcknowledges %v", p.SelectAcknowledges)
+		}
+
+		return []map[string]interface{}{
+			{
+				"eventid":      "11",
+				"acknowledged": "1",
+				"acknowledges": []map[string]interface{}{
+					{"acknowledgeid": "1", "userid": "1", "eventid": "11", "clock": "1590000000", "message": "Looking", "action": "4"},
+					{"acknowledgeid": "2", "userid": "3", "eventid": "11", "clock": "1590000060", "message": "Fixing", "action": "6"},
+				},
+			},
+		}, nil
+	})
+	defer closeMock()
+
+	pObjects, _, err := z.ProblemGet(ProblemGetParams{
+		SelectAcknowledges: SelectExtendedOutput,
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		t.Fatal("Problem acknowledges error:", err)
+	}
+
+	if len(pObjects) != 1 || pObjects[0].IsAcknowledged() == false {
+		t.Fatalf("Problem acknowledges error: unexpected result %v", pObjects)
+	}
+
+	if len(pObjects[0].Acknowledges) != 2 || pObjects[0].Acknowledges[1].UserID != 3 || pObjects[0].Acknowledges[1].Action&ProblemAcknowledgeActionAcknowledge == 0 {
+		t.Errorf("Problem acknowledges error: unexpected acknowledges %v", pObjects[0].Acknowledges)
+	}
+
+	t.Logf("Problem acknowledges: success")
+}
+
+func TestProblemIndexByTag(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "problem.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return []map[string]interface{}{
+			{"eventid": "1", "tags": []map[string]interface{}{{"tag": "service", "value": "db"}}},
+			{"eventid": "2", "tags": []map[string]interface{}{{"tag": "env", "value": "prod"}, {"tag": "service", "value": "web"}}},
+			{"eventid": "3", "tags": []map[string]interface{}{{"tag": "env", "value": "prod"}}},
+		}, nil
+	})
+	defer closeMock()
+
+	index, err := z.IndexProblemsByTag([]int{1}, "service")
+	if err != nil {
+		t.Fatal("Problem index by tag error:", err)
+	}
+
+	ids := make(map[string][]int)
+	for v, pObjects := range index {
+		for _, p := range pObjects {
+			ids[v] = append(ids[v], p.EventID)
+		}
+	}
+
+	expected := map[string][]int{
+		"db":  {1},
+		"web": {2},
+		"":    {3},
+	}
+
+	if reflect.DeepEqual(ids, expected) == false {
+		t.Errorf("Problem index by tag error: unexpected result %v", ids)
+	}
+
+	t.Logf("Problem index by tag: success")
+}
+
+func TestProblemGetAtLeast(t *testing.T) {
+
+	var severities []int
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p ProblemGetParams
+
+		if method != "problem.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		severities = p.Severities
+
+		return []map[string]interface{}{
+			{"eventid": "1", "severity": "4"},
+		}, nil
+	})
+	defer closeMock()
+
+	pObjects, err := z.GetProblemsAtLeast(ProblemSeverityAverage, []int{1})
+	if err != nil {
+		t.Fatal("Problem get at least error:", err)
+	}
+
+	if reflect.DeepEqual(severities, []int{3, 4, 5}) == false {
+		t.Errorf("Problem get at least error: unexpected severities %v", severities)
+	}
+
+	if len(pObjects) != 1 || pObjects[0].EventID != 1 {
+		t.Errorf("Problem get at least error: unexpected problems %v", pObjects)
+	}
+
+	for _, s := range []int{-1, 6} {
+		if _, err := z.GetProblemsAtLeast(s, nil); err == nil {
+			t.Errorf("Problem get at least error: expected error for severity %d", s)
+		}
+	}
+
+	t.Logf("Problem get at least: success")
+}
+
+func TestProblemOpData(t *testing.T) {
+
+	var (
+		version = "5.0.3"
+		output  json.RawMessage
+	)
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "apiinfo.version":
+			return version, nil
+		case "problem.get":
+
+			var p struct {
+				Output json.RawMessage `json:"output"`
+			}
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+			output = p.Output
+
+			return []map[string]interface{}{
+				{"eventid": "1", "name": "High CPU load", "opdata": "Load: 7.25 (threshold: {$LOAD.WARN})"},
+			}, nil
+		case "usermacro.get":
+
+			var p struct {
+				Filter map[string]string `json:"filter"`
+			}
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			if p.Filter["macro"] != "{$LOAD.WARN}" {
+				return []interface{}{}, nil
+			}
+
+			return []map[string]interface{}{
+				{"hostmacroid": "1", "hostid": "10084", "macro": "{$LOAD.WARN}", "value": "5"},
+			}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	pObjects, err := z.GetProblemsWithOpData(ProblemGetParams{
+		GetParameters: GetParameters{
+			Output: SelectFields{"eventid", "name"},
+		},
+	})
+	if err != nil {
+		t.Fatal("Problem opdata error:", err)
+	}
+
+	if string(output) != `["eventid","name","opdata"]` {
+		t.Errorf("Problem opdata error: unexpected output %s", string(output))
+	}
+
+	if len(pObjects) != 1 || pObjects[0].OpData != "Load: 7.25 (threshold: {$LOAD.WARN})" {
+		t.Fatalf("Problem opdata error: unexpected problems %v", pObjects)
+	}
+
+	opdata, err := z.ResolveOpDataMacros(10084, pObjects[0].OpData)
+	if err != nil {
+		t.Fatal("Problem opdata error:", err)
+	}
+
+	if opdata != "Load: 7.25 (threshold: 5)" {
+		t.Errorf("Problem opdata error: unexpected resolved opdata %s", opdata)
+	}
+
+	// Before Zabbix 5.0
+	version = "4.4.10"
+	z.apiVersion = ""
+
+	if _, err := z.GetProblemsWithOpData(ProblemGetParams{}); err == nil {
+		t.Error("Problem opdata error: expected version error")
+	}
+
+	t.Logf("Problem opdata: success")
+}
+
+func TestProblemResolveNames(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "problem.get":
+			return []map[string]interface{}{
+				{"eventid": "1", "source": "0", "object": "0", "objectid": "101", "name": "Disk is full on web01"},
+				{"eventid": "2", "source": "0", "object": "0", "objectid": "102", "name": ""},
+			}, nil
+		case "trigger.get":
+
+			var p TriggerGetParams
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			if reflect.DeepEqual(p.TriggerIDs, []int{102}) == false {
+				return nil, fmt.Errorf("unexpected triggerids %v", p.TriggerIDs)
+			}
+
+			return []map[string]interface{}{
+				{"triggerid": "102", "description": "High CPU load"},
+			}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	pObjects, _, err := z.ProblemGet(ProblemGetParams{
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		t.Fatal("Problem resolve names error:", err)
+	}
+
+	names, err := z.ResolveProblemNames(pObjects)
+	if err != nil {
+		t.Fatal("Problem resolve names error:", err)
+	}
+
+	expected := map[int]string{
+		1: "Disk is full on web01",
+		2: "High CPU load",
+	}
+
+	if reflect.DeepEqual(names, expected) == false {
+		t.Errorf("Problem resolve names error: unexpected result %v", names)
+	}
+
+	t.Logf("Problem resolve names: success")
+}
+
+func TestProblemCloseGroupProblems(t *testing.T) {
+
+	var acknowledged []interface{}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "problem.get":
+			return []map[string]interface{}{
+				{"eventid": "1", "objectid": "101"},
+				{"eventid": "2", "objectid": "102"},
+				{"eventid": "3", "objectid": "103"},
+			}, nil
+		case "trigger.get":
+			return []map[string]interface{}{
+				{"triggerid": "101", "manual_close": "1"},
+				{"triggerid": "102", "manual_close": "0"},
+				{"triggerid": "103", "manual_close": "1"},
+			}, nil
+		case "event.acknowledge":
+			var p map[string]interface{}
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+			if p["action"] != float64(EventAcknowledgeActionClose|EventAcknowledgeActionMessage) || p["message"] != "maintenance" {
+				return nil, fmt.Errorf("unexpected params %s", params)
+			}
+			ids := p["eventids"].([]interface{})
+			acknowledged = append(acknowledged, ids...)
+			return map[string]interface{}{"eventids": ids}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	closed, err := z.CloseGroupProblems(1, "maintenance")
+
+	e, ok := err.(*ProblemsNotClosableError)
+	if ok == false {
+		t.Fatal("Problem close group problems error: unexpected error", err)
+	}
+
+	if reflect.DeepEqual(e.EventIDs, []int{2}) == false {
+		t.Errorf("Problem close group problems error: unexpected skipped problems %v", e.EventIDs)
+	}
+
+	if reflect.DeepEqual(closed, []int{1, 3}) == false {
+		t.Errorf("Problem close group problems error: unexpected closed problems %v", closed)
+	}
+
+	if len(acknowledged) != 2 {
+		t.Errorf("Problem close group problems error: unexpected acknowledged problems %v", acknowledged)
+	}
+
+	t.Logf("Problem close group problems: success")
+}
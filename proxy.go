@@ -0,0 +1,303 @@
+package zabbix
+
+import (
+	"fmt"
+	"sync"
+)
+
+// For `ProxyObject` field: `Status`
+const (
+	ProxyStatusActive  = 5
+	ProxyStatusPassive = 6
+)
+
+// For `ProxyObject` field: `AutoCompress`
+const (
+	ProxyAutoCompressDisabled = 0
+	ProxyAutoCompressEnabled  = 1
+)
+
+// For `ProxyInterfaceObject` field: `UseIP`
+const (
+	ProxyInterfaceUseipDNS = 0
+	ProxyInterfaceUseipIP  = 1
+)
+
+// ProxyObject struct is used to store proxy operations results
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/proxy/object#proxy
+type ProxyObject struct {
+	ProxyID        int    `json:"proxyid,omitempty"`
+	Host           string `json:"host,omitempty"`
+	Status         int    `json:"status,omitempty"` // has defined consts, see above
+	Description    string `json:"description,omitempty"`
+	LastAccess     int    `json:"lastaccess,omitempty"`
+	TLSConnect     int    `json:"tls_connect,omitempty"` // see `TLSConnect*` consts
+	TLSAccept      int    `json:"tls_accept,omitempty"`  // see `TLSAccept*` consts
+	TLSIssuer      string `json:"tls_issuer,omitempty"`
+	TLSSubject     string `json:"tls_subject,omitempty"`
+	TLSPSKIdentity string `json:"tls_psk_identity,omitempty"`
+	TLSPSK         string `json:"tls_psk,omitempty"`
+	ProxyAddress   string `json:"proxy_address,omitempty"`
+	AutoCompress   int    `json:"auto_compress,omitempty"` // has defined consts, see above
+
+	Hosts     []HostObject          `json:"hosts,omitempty"`
+	Interface *ProxyInterfaceObject `json:"interface,omitempty"` // Used for passive proxies only
+}
+
+// ProxyInterfaceObject struct is used to store proxy interface
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/proxy/object#proxy_interface
+type ProxyInterfaceObject struct {
+	InterfaceID int    `json:"interfaceid,omitempty"`
+	DNS         string `json:"dns"`
+	IP          string `json:"ip"`
+	Port        string `json:"port"`
+	UseIP       int    `json:"useip"` // has defined consts, see above
+}
+
+// ProxyConfig struct is used to store proxy configuration required to recreate the proxy:
+// proxy itself (by `ProxyCreate`), its interface and assigned hosts (by `host.massupdate`)
+type ProxyConfig struct {
+	Proxy     ProxyObject
+	Interface *ProxyInterfaceObject // nil for active proxies
+	HostIDs   []int
+}
+
+// proxyCache is used to store proxy IDs and names
+type proxyCache struct {
+	mu    sync.Mutex
+	names map[int]string
+	ids   map[string]int
+}
+
+// ProxyGetParams struct is used for proxy get requests
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/proxy/get#parameters
+type ProxyGetParams struct {
+	GetParameters
+
+	ProxyIDs []int `json:"proxyids,omitempty"`
+
+	SelectHosts     SelectQuery `json:"selectHosts,omitempty"`
+	SelectInterface SelectQuery `json:"selectInterface,omitempty"`
+}
+
+// Structure to store creation result
+type proxyCreateResult struct {
+	ProxyIDs []int `json:"proxyids"`
+}
+
+// Structure to store deletion result
+type proxyDeleteResult struct {
+	ProxyIDs []int `json:"proxyids"`
+}
+
+// ProxyGet gets proxies
+func (z *Context) ProxyGet(params ProxyGetParams) ([]ProxyObject, int, error) {
+
+	var result []ProxyObject
+
+	status, err := z.request("proxy.get", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result, status, nil
+}
+
+// ProxyCreate creates proxies
+func (z *Context) ProxyCreate(params []ProxyObject) ([]int, int, error) {
+
+	var result proxyCreateResult
+
+	status, err := z.request("proxy.create", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.ProxyIDs, status, nil
+}
+
+// ProxyDelete deletes proxies
+func (z *Context) ProxyDelete(proxyIDs []int) ([]int, int, error) {
+
+	var result proxyDeleteResult
+
+	status, err := z.request("proxy.delete", proxyIDs, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.ProxyIDs, status, nil
+}
+
+// GetProxyHostMap returns IDs of hosts monitored by each proxy within a single `proxy.get` call.
+// Result is a map of proxy ID to host IDs, proxies without hosts are included with empty slices
+func (z *Context) GetProxyHostMap() (map[int][]int, error) {
+
+	pObjects, _, err := z.ProxyGet(ProxyGetParams{
+		SelectHosts: SelectFields{"hostid"},
+		GetParameters: GetParameters{
+			Output: SelectFields{"proxyid"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := make(map[int][]int)
+
+	for _, p := range pObjects {
+
+		hostIDs := []int{}
+
+		for _, h := range p.Hosts {
+			hostIDs = append(hostIDs, h.HostID)
+		}
+
+		r[p.ProxyID] = hostIDs
+	}
+
+	return r, nil
+}
+
+// ExportProxyConfig gets configuration of the proxy with specified ID.
+// Read-only proxy fields (`ProxyID`, `LastAccess`, `InterfaceID`) are cleared in result,
+// so the proxy may be recreated from the config as is
+func (z *Context) ExportProxyConfig(proxyID int) (ProxyConfig, error) {
+
+	pObjects, _, err := z.ProxyGet(ProxyGetParams{
+		ProxyIDs:        []int{proxyID},
+		SelectHosts:     SelectFields{"hostid"},
+		SelectInterface: SelectExtendedOutput,
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return ProxyConfig{}, err
+	}
+
+	if len(pObjects) == 0 {
+		return ProxyConfig{}, fmt.Errorf("proxy with id %d not found", proxyID)
+	}
+
+	p := pObjects[0]
+
+	c := ProxyConfig{
+		Interface: p.Interface,
+		HostIDs:   []int{},
+	}
+
+	for _, h := range p.Hosts {
+		c.HostIDs = append(c.HostIDs, h.HostID)
+	}
+
+	if c.Interface != nil {
+		c.Interface.InterfaceID = 0
+	}
+
+	p.ProxyID = 0
+	p.LastAccess = 0
+	p.Hosts = nil
+	p.Interface = nil
+	c.Proxy = p
+
+	return c, nil
+}
+
+// ProxyName returns name of the proxy with specified ID. Names of all proxies are requested
+// on the first call (or if ID is not found in cache) and cached, see also `InvalidateProxyCache()`
+func (z *Context) ProxyName(proxyID int) (string, error) {
+
+	c := z.proxyCache()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if n, b := c.names[proxyID]; b == true {
+		return n, nil
+	}
+
+	if err := z.proxyCacheLoad(c); err != nil {
+		return "", err
+	}
+
+	if n, b := c.names[proxyID]; b == true {
+		return n, nil
+	}
+
+	return "", fmt.Errorf("proxy with id %d not found", proxyID)
+}
+
+// ProxyID returns ID of the proxy with specified name, proxies are cached the same way as for `ProxyName()`
+func (z *Context) ProxyID(name string) (int, error) {
+
+	c := z.proxyCache()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if id, b := c.ids[name]; b == true {
+		return id, nil
+	}
+
+	if err := z.proxyCacheLoad(c); err != nil {
+		return 0, err
+	}
+
+	if id, b := c.ids[name]; b == true {
+		return id, nil
+	}
+
+	return 0, fmt.Errorf("proxy with name %s not found", name)
+}
+
+// InvalidateProxyCache clears proxies cache used by `ProxyName()` and `ProxyID()`
+func (z *Context) InvalidateProxyCache() {
+
+	c := z.proxyCache()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.names = nil
+	c.ids = nil
+}
+
+// proxyCache returns proxies cache of the context, cache is created on first use
+func (z *Context) proxyCache() *proxyCache {
+
+	contextCachesMu.Lock()
+	defer contextCachesMu.Unlock()
+
+	if z.proxies == nil {
+		z.proxies = &proxyCache{}
+	}
+
+	return z.proxies
+}
+
+// proxyCacheLoad requests all proxies and replaces cache content. Cache must be locked by caller
+func (z *Context) proxyCacheLoad(c *proxyCache) error {
+
+	pObjects, _, err := z.ProxyGet(ProxyGetParams{
+		GetParameters: GetParameters{
+			Output: SelectFields{"proxyid", "host"},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	c.names = make(map[int]string)
+	c.ids = make(map[string]int)
+
+	for _, p := range pObjects {
+		c.names[p.ProxyID] = p.Host
+		c.ids[p.Host] = p.ProxyID
+	}
+
+	return nil
+}
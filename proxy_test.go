@@ -0,0 +1,301 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+const (
+	testProxyName = "testProxy"
+)
+
+func TestProxyCRUD(t *testing.T) {
+
+	var z Context
+
+	// Login
+	loginTest(&z, t)
+	defer logoutTest(&z, t)
+
+	// Create and delete
+	pCreatedIDs := testProxyCreate(t, z)
+	defer testProxyDelete(t, z, pCreatedIDs)
+
+	// Get
+	testProxyGet(t, z, pCreatedIDs)
+}
+
+func TestProxyHostMap(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "proxy.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return []map[string]interface{}{
+			{"proxyid": "10", "hosts": []map[string]interface{}{{"hostid": "101"}, {"hostid": "102"}}},
+			{"proxyid": "20", "hosts": []map[string]interface{}{{"hostid": "201"}}},
+		}, nil
+	})
+	defer closeMock()
+
+	m, err := z.GetProxyHostMap()
+	if err != nil {
+		t.Fatal("Proxy host map error:", err)
+	}
+
+	expected := map[int][]int{
+		10: {101, 102},
+		20: {201},
+	}
+
+	if reflect.DeepEqual(m, expected) == false {
+		t.Errorf("Proxy host map error: unexpected result %v", m)
+	}
+
+	t.Logf("Proxy host map: success")
+}
+
+func TestProxyExportConfig(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p ProxyGetParams
+
+		if method != "proxy.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		if len(p.ProxyIDs) != 1 || p.ProxyIDs[0] != 10 {
+			return []interface{}{}, nil
+		}
+
+		return []map[string]interface{}{
+			{
+				"proxyid":    "10",
+				"host":       "passiveProxy",
+				"status":     "6",
+				"lastaccess": "1600000000",
+				"hosts":      []map[string]interface{}{{"hostid": "101"}, {"hostid": "102"}},
+				"interface": map[string]interface{}{
+					"interfaceid": "5",
+					"dns":         "",
+					"ip":          "10.0.0.1",
+					"port":        "10051",
+					"useip":       "1",
+				},
+			},
+		}, nil
+	})
+	defer closeMock()
+
+	c, err := z.ExportProxyConfig(10)
+	if err != nil {
+		t.Fatal("Proxy export config error:", err)
+	}
+
+	expected := ProxyConfig{
+		Proxy: ProxyObject{
+			Host:   "passiveProxy",
+			Status: ProxyStatusPassive,
+		},
+		Interface: &ProxyInterfaceObject{
+			IP:    "10.0.0.1",
+			Port:  "10051",
+			UseIP: ProxyInterfaceUseipIP,
+		},
+		HostIDs: []int{101, 102},
+	}
+
+	if reflect.DeepEqual(c, expected) == false {
+		t.Errorf("Proxy export config error: unexpected result %+v", c)
+	}
+
+	if _, err := z.ExportProxyConfig(20); err == nil {
+		t.Error("Proxy export config error: expected error for missing proxy")
+	}
+
+	t.Logf("Proxy export config: success")
+}
+
+func TestProxyNameCache(t *testing.T) {
+
+	var calls int
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "proxy.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		calls++
+
+		return []map[string]interface{}{
+			{"proxyid": "10", "host": "proxy-a"},
+			{"proxyid": "20", "host": "proxy-b"},
+		}, nil
+	})
+	defer closeMock()
+
+	for i := 0; i < 2; i++ {
+
+		n, err := z.ProxyName(10)
+		if err != nil {
+			t.Fatal("Proxy name cache error:", err)
+		}
+
+		if n != "proxy-a" {
+			t.Errorf("Proxy name cache error: unexpected name %s", n)
+		}
+	}
+
+	id, err := z.ProxyID("proxy-b")
+	if err != nil {
+		t.Fatal("Proxy name cache error:", err)
+	}
+
+	if id != 20 {
+		t.Errorf("Proxy name cache error: unexpected ID %d", id)
+	}
+
+	if calls != 1 {
+		t.Errorf("Proxy name cache error: expected 1 request, got %d", calls)
+	}
+
+	// Invalidated cache is reloaded
+	z.InvalidateProxyCache()
+
+	if _, err := z.ProxyName(20); err != nil {
+		t.Fatal("Proxy name cache error:", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Proxy name cache error: expected 2 requests, got %d", calls)
+	}
+
+	// Missing proxy
+	if _, err := z.ProxyName(30); err == nil {
+		t.Error("Proxy name cache error: expected error for missing proxy")
+	}
+
+	t.Logf("Proxy name cache: success")
+}
+
+func TestProxyNameCacheConcurrent(t *testing.T) {
+
+	var (
+		mu    sync.Mutex
+		calls int
+		wg    sync.WaitGroup
+	)
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "proxy.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		mu.Lock()
+		calls++
+		mu.Unlock()
+
+		return []map[string]interface{}{
+			{"proxyid": "10", "host": "proxy-a"},
+		}, nil
+	})
+	defer closeMock()
+
+	// Concurrent first calls must share the same cache
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := z.ProxyName(10); err != nil {
+				t.Error("Proxy name cache concurrent error:", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("Proxy name cache concurrent error: expected 1 request, got %d", calls)
+	}
+
+	t.Logf("Proxy name cache concurrent: success")
+}
+
+func testProxyCreate(t *testing.T, z Context) []int {
+
+	pCreatedIDs, _, err := z.ProxyCreate([]ProxyObject{
+		{
+			Host:   testProxyName,
+			Status: ProxyStatusActive,
+		},
+	})
+	if err != nil {
+		t.Fatal("Proxy create error:", err)
+	}
+
+	if len(pCreatedIDs) == 0 {
+		t.Fatal("Proxy create error: empty IDs array")
+	}
+
+	t.Logf("Proxy create: success")
+
+	return pCreatedIDs
+}
+
+func testProxyDelete(t *testing.T, z Context, pCreatedIDs []int) []int {
+
+	pDeletedIDs, _, err := z.ProxyDelete(pCreatedIDs)
+	if err != nil {
+		t.Fatal("Proxy delete error:", err)
+	}
+
+	if len(pDeletedIDs) == 0 {
+		t.Fatal("Proxy delete error: empty IDs array")
+	}
+
+	if reflect.DeepEqual(pDeletedIDs, pCreatedIDs) == false {
+		t.Fatal("Proxy delete error: IDs arrays for created and deleted proxy are mismatch")
+	}
+
+	t.Logf("Proxy delete: success")
+
+	return pDeletedIDs
+}
+
+func testProxyGet(t *testing.T, z Context, pCreatedIDs []int) []ProxyObject {
+
+	pObjects, _, err := z.ProxyGet(ProxyGetParams{
+		ProxyIDs:    pCreatedIDs,
+		SelectHosts: SelectExtendedOutput,
+		GetParameters: GetParameters{
+			Filter: map[string]interface{}{
+				"host": testProxyName,
+			},
+			Output: SelectExtendedOutput,
+		},
+	})
+
+	if err != nil {
+		t.Error("Proxy get error:", err)
+	} else {
+		if len(pObjects) == 0 {
+			t.Error("Proxy get error: unable to find created proxy")
+		} else {
+			t.Logf("Proxy get: success")
+		}
+	}
+
+	return pObjects
+}
@@ -0,0 +1,389 @@
+package zabbix
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// For `ServiceObject` field: `Algorithm`
+const (
+	ServiceAlgorithmNone     = 0 // used before Zabbix 6.0 only
+	ServiceAlgorithmMostCrit = 1
+	ServiceAlgorithmAllCrit  = 2
+)
+
+// ServiceStatusOK is returned by `GetServiceStatuses()` for services without problems
+const ServiceStatusOK = -1
+
+// Zabbix API version services are reworked in (status rules, `-1` status for OK services)
+const serviceStatusRulesVersion = "6.0"
+
+// ServiceObject struct is used to store service operations results
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/service/object#service
+type ServiceObject struct {
+	ServiceID int    `json:"serviceid,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Algorithm int    `json:"algorithm,omitempty"` // has defined consts, see above
+	SortOrder int    `json:"sortorder,omitempty"`
+	Status    int    `json:"status,omitempty"`    // before Zabbix 6.0 `0` is used for OK services, since Zabbix 6.0 `-1`
+	TriggerID int    `json:"triggerid,omitempty"` // used before Zabbix 6.0 only
+}
+
+// ServiceGetParams struct is used for service get requests
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/service/get#parameters
+type ServiceGetParams struct {
+	GetParameters
+
+	ServiceIDs []int `json:"serviceids,omitempty"`
+
+	// SelectParent       SelectQuery `json:"selectParent,omitempty"` // not implemented yet
+	// SelectDependencies SelectQuery `json:"selectDependencies,omitempty"` // not implemented yet
+	// SelectTimes        SelectQuery `json:"selectTimes,omitempty"` // not implemented yet
+}
+
+// ServiceGet gets services
+func (z *Context) ServiceGet(params ServiceGetParams) ([]ServiceObject, int, error) {
+
+	var result []ServiceObject
+
+	status, err := z.request("service.get", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result, status, nil
+}
+
+// GetServiceStatuses returns current statuses of the services as a map of service ID to status.
+// Status is either `ServiceStatusOK` or severity of the service problem (see `ProblemSeverity*` consts).
+// Before Zabbix 6.0 status is calculated by service algorithm and `0` is used for OK services,
+// since Zabbix 6.0 it is calculated by status rules and `-1` is used. Both are returned as `ServiceStatusOK`
+func (z *Context) GetServiceStatuses(serviceIDs []int) (map[int]int, error) {
+
+	rules, err := z.apiVersionAtLeast(serviceStatusRulesVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	sObjects, _, err := z.ServiceGet(ServiceGetParams{
+		ServiceIDs: serviceIDs,
+		GetParameters: GetParameters{
+			Output: SelectFields{"serviceid", "status"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := make(map[int]int)
+
+	for _, s := range sObjects {
+		if rules == false && s.Status == 0 {
+			s.Status = ServiceStatusOK
+		}
+		r[s.ServiceID] = s.Status
+	}
+
+	return r, nil
+}
+
+// timeInterval is used to store time interval `[from, till)` as Unix timestamps
+type timeInterval struct {
+	from int64
+	till int64
+}
+
+// GetServiceSLAExcludingMaintenance calculates SLA (percentage of time without problems) of the service
+// within `[from, to)` period excluding maintenance windows of the service trigger hosts. Downtime is
+// calculated from problem events of the service trigger, so services linked to triggers are supported
+// only (before Zabbix 6.0), error is returned for other services (e.g. parent ones, which status depends
+// on child services). Only one-time maintenance periods are taken into account
+func (z *Context) GetServiceSLAExcludingMaintenance(serviceID int, from, to time.Time) (float64, error) {
+
+	if to.After(from) == false {
+		return 0, fmt.Errorf("invalid period: %s - %s", from, to)
+	}
+
+	rules, err := z.apiVersionAtLeast(serviceStatusRulesVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	if rules == true {
+		return 0, fmt.Errorf("service SLA by trigger is not supported since Zabbix API version %s (current version is %s)", serviceStatusRulesVersion, z.apiVersionCached())
+	}
+
+	sObjects, _, err := z.ServiceGet(ServiceGetParams{
+		ServiceIDs: []int{serviceID},
+		GetParameters: GetParameters{
+			Output: SelectFields{"serviceid", "triggerid"},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("get service error: %v", err)
+	}
+
+	if len(sObjects) == 0 {
+		return 0, fmt.Errorf("service with id %d not found", serviceID)
+	}
+
+	period := timeInterval{
+		from: from.Unix(),
+		till: to.Unix(),
+	}
+
+	// Status of service without trigger depends on child services, so downtime can not be calculated
+	if sObjects[0].TriggerID == 0 {
+		return 0, fmt.Errorf("service with id %d is not linked to a trigger, SLA can not be calculated", serviceID)
+	}
+
+	downtime, err := z.serviceDowntime(sObjects[0].TriggerID, period)
+	if err != nil {
+		return 0, err
+	}
+
+	maintenances, err := z.serviceMaintenances(sObjects[0].TriggerID, period)
+	if err != nil {
+		return 0, err
+	}
+
+	var d int64
+	for _, i := range intervalsSubtract(downtime, maintenances) {
+		d += i.till - i.from
+	}
+
+	return 100 * (1 - float64(d)/float64(period.till-period.from)), nil
+}
+
+// serviceDowntime returns merged problem intervals of the trigger within the period. Only problem events
+// which were in the problem state within the period are requested (including ones started before it)
+func (z *Context) serviceDowntime(triggerID int, period timeInterval) ([]timeInterval, error) {
+
+	params := EventGetParams{
+		Source:          EventSourceTrigger,
+		Object:          EventObjectTrigger,
+		ObjectIDs:       []int{triggerID},
+		ProblemTimeFrom: int(period.from),
+		ProblemTimeTill: int(period.till),
+		GetParameters: GetParameters{
+			Output: SelectFields{"eventid", "clock", "r_eventid"},
+		},
+	}
+	params.OnlyProblems()
+
+	problems, _, err := z.EventGet(params)
+	if err != nil {
+		return nil, fmt.Errorf("get problem events error: %v", err)
+	}
+
+	var rIDs []int
+	for _, e := range problems {
+		if e.REventID != 0 {
+			rIDs = append(rIDs, e.REventID)
+		}
+	}
+
+	recovered := make(map[int]int64)
+
+	if len(rIDs) > 0 {
+
+		recoveries, _, err := z.EventGet(EventGetParams{
+			EventIDs: rIDs,
+			GetParameters: GetParameters{
+				Output: SelectFields{"eventid", "clock"},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get recovery events error: %v", err)
+		}
+
+		for _, e := range recoveries {
+			recovered[e.EventID] = int64(e.Clock)
+		}
+	}
+
+	var r []timeInterval
+
+	for _, e := range problems {
+
+		i := timeInterval{
+			from: int64(e.Clock),
+			till: period.till,
+		}
+
+		if c, b := recovered[e.REventID]; b == true {
+			i.till = c
+		}
+
+		r = append(r, i)
+	}
+
+	return intervalsClip(r, period), nil
+}
+
+// serviceMaintenances returns merged one-time maintenance intervals of the trigger hosts
+// (assigned directly or via host groups) within the period
+func (z *Context) serviceMaintenances(triggerID int, period timeInterval) ([]timeInterval, error) {
+
+	tObjects, _, err := z.TriggerGet(TriggerGetParams{
+		TriggerIDs:   []int{triggerID},
+		SelectHosts:  SelectFields{"hostid"},
+		SelectGroups: SelectFields{"groupid"},
+		GetParameters: GetParameters{
+			Output: SelectFields{"triggerid"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get trigger error: %v", err)
+	}
+
+	if len(tObjects) == 0 {
+		return nil, fmt.Errorf("trigger with id %d not found", triggerID)
+	}
+
+	var hostIDs, groupIDs []int
+
+	for _, h := range tObjects[0].Hosts {
+		hostIDs = append(hostIDs, h.HostID)
+	}
+
+	for _, g := range tObjects[0].Groups {
+		groupIDs = append(groupIDs, g.GroupID)
+	}
+
+	var queries []MaintenanceGetParams
+
+	if len(hostIDs) > 0 {
+		queries = append(queries, MaintenanceGetParams{HostIDs: hostIDs})
+	}
+
+	if len(groupIDs) > 0 {
+		queries = append(queries, MaintenanceGetParams{GroupIDs: groupIDs})
+	}
+
+	var r []timeInterval
+
+	seen := make(map[int]bool)
+
+	for _, q := range queries {
+
+		q.SelectTimeperiods = SelectExtendedOutput
+		q.Output = SelectFields{"maintenanceid", "active_since", "active_till"}
+
+		mObjects, _, err := z.MaintenanceGet(q)
+		if err != nil {
+			return nil, fmt.Errorf("get maintenances error: %v", err)
+		}
+
+		for _, m := range mObjects {
+
+			if seen[m.MaintenanceID] == true {
+				continue
+			}
+			seen[m.MaintenanceID] = true
+
+			active := timeInterval{
+				from: int64(m.ActiveSince),
+				till: int64(m.ActiveTill),
+			}
+
+			for _, tp := range m.Timeperiods {
+				if tp.TimeperiodType == MaintenanceTimeperiodTypeOneTime {
+					r = append(r, intervalsClip([]timeInterval{
+						{
+							from: int64(tp.StartDate),
+							till: int64(tp.StartDate + tp.Period),
+						},
+					}, active)...)
+				}
+			}
+		}
+	}
+
+	return intervalsClip(r, period), nil
+}
+
+// intervalsClip returns merged intervals clipped to the period, empty intervals are dropped
+func intervalsClip(intervals []timeInterval, period timeInterval) []timeInterval {
+
+	var r []timeInterval
+
+	for _, i := range intervals {
+
+		if i.from < period.from {
+			i.from = period.from
+		}
+
+		if i.till > period.till {
+			i.till = period.till
+		}
+
+		if i.from < i.till {
+			r = append(r, i)
+		}
+	}
+
+	return intervalsMerge(r)
+}
+
+// intervalsMerge returns sorted intervals with overlapping ones merged
+func intervalsMerge(intervals []timeInterval) []timeInterval {
+
+	s := append([]timeInterval(nil), intervals...)
+
+	sort.Slice(s, func(i, j int) bool {
+		return s[i].from < s[j].from
+	})
+
+	var r []timeInterval
+
+	for _, i := range s {
+
+		if len(r) > 0 && i.from <= r[len(r)-1].till {
+			if i.till > r[len(r)-1].till {
+				r[len(r)-1].till = i.till
+			}
+			continue
+		}
+
+		r = append(r, i)
+	}
+
+	return r
+}
+
+// intervalsSubtract returns parts of intervals `a` not covered by intervals `b`.
+// Both `a` and `b` must be merged (see `intervalsMerge()`)
+func intervalsSubtract(a, b []timeInterval) []timeInterval {
+
+	var r []timeInterval
+
+	for _, i := range a {
+
+		for _, e := range b {
+
+			if e.till <= i.from || e.from >= i.till {
+				continue
+			}
+
+			if e.from > i.from {
+				r = append(r, timeInterval{from: i.from, till: e.from})
+			}
+
+			i.from = e.till
+
+			if i.from >= i.till {
+				break
+			}
+		}
+
+		if i.from < i.till {
+			r = append(r, i)
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,184 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestServiceStatuses(t *testing.T) {
+
+	tests := []struct {
+		version  string
+		services []map[string]interface{}
+		expected map[int]int
+	}{
+		{
+			version: "5.0.2",
+			services: []map[string]interface{}{
+				{"serviceid": "1", "status": "0"},
+				{"serviceid": "2", "status": "4"},
+			},
+			expected: map[int]int{1: ServiceStatusOK, 2: ProblemSeverityHigh},
+		},
+		{
+			version: "6.0.0",
+			services: []map[string]interface{}{
+				{"serviceid": "1", "status": "-1"},
+				{"serviceid": "2", "status": "0"},
+			},
+			expected: map[int]int{1: ServiceStatusOK, 2: ProblemSeverityNotClassified},
+		},
+	}
+
+	for _, tt := range tests {
+
+		z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+			switch method {
+			case "apiinfo.version":
+				return tt.version, nil
+			case "service.get":
+				return tt.services, nil
+			}
+
+			return nil, fmt.Errorf("unexpected method %s", method)
+		})
+
+		statuses, err := z.GetServiceStatuses([]int{1, 2})
+		closeMock()
+
+		if err != nil {
+			t.Fatalf("Service statuses error (%s): %v", tt.version, err)
+		}
+
+		if reflect.DeepEqual(statuses, tt.expected) == false {
+			t.Errorf("Service statuses error (%s): unexpected statuses %v", tt.version, statuses)
+		}
+	}
+
+	t.Logf("Service statuses: success")
+}
+
+func TestServiceSLAExcludingMaintenance(t *testing.T) {
+
+	var maintenances []map[string]interface{}
+
+	services := []map[string]interface{}{{"serviceid": "1", "triggerid": "13491"}}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "apiinfo.version":
+			return "5.0.2", nil
+		case "service.get":
+			return services, nil
+		case "trigger.get":
+			return []map[string]interface{}{
+				{
+					"triggerid": "13491",
+					"hosts":     []map[string]interface{}{{"hostid": "10084"}},
+					"groups":    []map[string]interface{}{{"groupid": "2"}},
+				},
+			}, nil
+		case "event.get":
+
+			var p EventGetParams
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			// Recovery events
+			if len(p.EventIDs) > 0 {
+				return []map[string]interface{}{{"eventid": "2", "clock": "4000"}}, nil
+			}
+
+			if p.ObjectIDs[0] != 13491 || p.ProblemTimeFrom != 1000 || p.ProblemTimeTill != 11000 || p.TimeFrom != 0 || p.TimeTill != 0 {
+				return nil, fmt.Errorf("unexpected params %s", string(params))
+			}
+
+			return []map[string]interface{}{
+				{"eventid": "1", "clock": "2000", "r_eventid": "2"},
+				{"eventid": "3", "clock": "10000", "r_eventid": "0"},
+			}, nil
+		case "maintenance.get":
+
+			var p MaintenanceGetParams
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			// Maintenance assigned to the host group
+			if len(p.GroupIDs) > 0 {
+				return maintenances, nil
+			}
+
+			return []map[string]interface{}{}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	from := time.Unix(1000, 0)
+	to := time.Unix(11000, 0)
+
+	// Downtime: 2000-4000 and 10000-11000
+	sla, err := z.GetServiceSLAExcludingMaintenance(1, from, to)
+	if err != nil {
+		t.Fatal("Service SLA excluding maintenance error:", err)
+	}
+
+	if sla != 70 {
+		t.Errorf("Service SLA excluding maintenance error: expected 70, got %v", sla)
+	}
+
+	// Maintenance covers 3000-3500 of the downtime, the second period does not overlap the downtime
+	maintenances = []map[string]interface{}{
+		{
+			"maintenanceid": "5",
+			"active_since":  "0",
+			"active_till":   "20000",
+			"timeperiods": []map[string]interface{}{
+				{"timeperiod_type": "0", "start_date": "3000", "period": "500"},
+				{"timeperiod_type": "0", "start_date": "5000", "period": "1000"},
+			},
+		},
+	}
+
+	sla, err = z.GetServiceSLAExcludingMaintenance(1, from, to)
+	if err != nil {
+		t.Fatal("Service SLA excluding maintenance error:", err)
+	}
+
+	if sla != 75 {
+		t.Errorf("Service SLA excluding maintenance error: expected 75, got %v", sla)
+	}
+
+	// Status of parent service depends on child services
+	services = []map[string]interface{}{{"serviceid": "1", "triggerid": "0"}}
+
+	if _, err := z.GetServiceSLAExcludingMaintenance(1, from, to); err == nil {
+		t.Error("Service SLA excluding maintenance error: expected error for service without trigger")
+	}
+
+	t.Logf("Service SLA excluding maintenance: success")
+}
+
+func TestServiceIntervalsSubtract(t *testing.T) {
+
+	a := intervalsMerge([]timeInterval{{from: 0, till: 100}, {from: 50, till: 150}, {from: 200, till: 300}})
+	b := intervalsMerge([]timeInterval{{from: 10, till: 20}, {from: 140, till: 210}, {from: 250, till: 400}})
+
+	expected := []timeInterval{{from: 0, till: 10}, {from: 20, till: 140}, {from: 210, till: 250}}
+
+	if r := intervalsSubtract(a, b); reflect.DeepEqual(r, expected) == false {
+		t.Errorf("Service intervals subtract error: unexpected result %v", r)
+	}
+
+	t.Logf("Service intervals subtract: success")
+}
@@ -1,5 +1,11 @@
 package zabbix
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // For `TemplateGetParams` field: `Evaltype`
 const (
 	TemplateEvaltypeAndOr = 0
@@ -76,6 +82,20 @@ type TemplateGetParams struct {
 	// SelectScreens         SelectQuery `json:"selectScreens,omitempty"` // not implemented yet
 }
 
+// TemplateDiff struct is used to store differences of the template B against the template A.
+// Items are identified by keys and triggers by descriptions (names). Added entities exist
+// in the template B only, removed ones in the template A only, changed exist in both templates
+// but have different configuration. All lists are sorted
+type TemplateDiff struct {
+	ItemsAdded   []string
+	ItemsRemoved []string
+	ItemsChanged []string
+
+	TriggersAdded   []string
+	TriggersRemoved []string
+	TriggersChanged []string
+}
+
 // Structure to store creation result
 type templateCreateResult struct {
 	TemplateIDs []int `json:"templateids"`
@@ -124,3 +144,182 @@ func (z *Context) TemplateDelete(templateIDs []int) ([]int, int, error) {
 
 	return result.TemplateIDs, status, nil
 }
+
+// WouldCreateTemplateCycle checks linking the template with `linkTemplateID` to the template with `templateID`
+// would introduce a cycle, i.e. `linkTemplateID` is the same template or `templateID` is already linked
+// to it (directly or via nested templates). Templates linked to `linkTemplateID` are walked level by level
+// (one `template.get` request per level) using `parentTemplates` selector
+func (z *Context) WouldCreateTemplateCycle(templateID, linkTemplateID int) (bool, error) {
+
+	if templateID == linkTemplateID {
+		return true, nil
+	}
+
+	visited := map[int]bool{
+		linkTemplateID: true,
+	}
+
+	queue := []int{linkTemplateID}
+
+	for len(queue) > 0 {
+
+		tObjects, _, err := z.TemplateGet(TemplateGetParams{
+			TemplateIDs:           queue,
+			SelectParentTemplates: SelectFields{"templateid"},
+			GetParameters: GetParameters{
+				Output: SelectFields{"templateid"},
+			},
+		})
+		if err != nil {
+			return false, err
+		}
+
+		queue = nil
+
+		for _, t := range tObjects {
+			for _, p := range t.ParentTemplates {
+
+				if p.TemplateID == templateID {
+					return true, nil
+				}
+
+				if visited[p.TemplateID] == false {
+					visited[p.TemplateID] = true
+					queue = append(queue, p.TemplateID)
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// DiffTemplates compares items and triggers of two templates (see `TemplateDiff`).
+// Trigger expressions are compared with the template names removed from item references
+func (z *Context) DiffTemplates(aID, bID int) (TemplateDiff, error) {
+
+	tObjects, _, err := z.TemplateGet(TemplateGetParams{
+		TemplateIDs: []int{aID, bID},
+		GetParameters: GetParameters{
+			Output: SelectFields{"templateid", "host"},
+		},
+	})
+	if err != nil {
+		return TemplateDiff{}, fmt.Errorf("get templates error: %v", err)
+	}
+
+	hosts := make(map[int]string)
+	for _, t := range tObjects {
+		hosts[t.TemplateID] = t.Host
+	}
+
+	for _, id := range []int{aID, bID} {
+		if _, ok := hosts[id]; ok == false {
+			return TemplateDiff{}, fmt.Errorf("template with id %d not found", id)
+		}
+	}
+
+	aItems, aTriggers, err := z.templateEntities(aID, hosts[aID])
+	if err != nil {
+		return TemplateDiff{}, err
+	}
+
+	bItems, bTriggers, err := z.templateEntities(bID, hosts[bID])
+	if err != nil {
+		return TemplateDiff{}, err
+	}
+
+	d := TemplateDiff{
+		ItemsAdded:      []string{},
+		ItemsRemoved:    []string{},
+		ItemsChanged:    []string{},
+		TriggersAdded:   []string{},
+		TriggersRemoved: []string{},
+		TriggersChanged: []string{},
+	}
+
+	for k, a := range aItems {
+		b, ok := bItems[k]
+		if ok == false {
+			d.ItemsRemoved = append(d.ItemsRemoved, k)
+			continue
+		}
+		if itemConfigDiffers(a, b) == true || a.Name != b.Name || a.Type != b.Type || a.ValueType != b.ValueType || a.Units != b.Units {
+			d.ItemsChanged = append(d.ItemsChanged, k)
+		}
+	}
+
+	for k := range bItems {
+		if _, ok := aItems[k]; ok == false {
+			d.ItemsAdded = append(d.ItemsAdded, k)
+		}
+	}
+
+	for k, a := range aTriggers {
+		b, ok := bTriggers[k]
+		if ok == false {
+			d.TriggersRemoved = append(d.TriggersRemoved, k)
+			continue
+		}
+		if a.Expression != b.Expression || a.RecoveryMode != b.RecoveryMode || a.RecoveryExpression != b.RecoveryExpression ||
+			a.Priority != b.Priority || a.Status != b.Status {
+			d.TriggersChanged = append(d.TriggersChanged, k)
+		}
+	}
+
+	for k := range bTriggers {
+		if _, ok := aTriggers[k]; ok == false {
+			d.TriggersAdded = append(d.TriggersAdded, k)
+		}
+	}
+
+	for _, l := range [][]string{d.ItemsAdded, d.ItemsRemoved, d.ItemsChanged, d.TriggersAdded, d.TriggersRemoved, d.TriggersChanged} {
+		sort.Strings(l)
+	}
+
+	return d, nil
+}
+
+// templateEntities gets items of the template indexed by keys and triggers indexed by descriptions.
+// Template name is removed from trigger expressions to make them comparable between templates
+func (z *Context) templateEntities(templateID int, host string) (map[string]ItemObject, map[string]TriggerObject, error) {
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{
+		TemplateIDs: []int{templateID},
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("get template items error: %v", err)
+	}
+
+	// Raw expressions contain function IDs which differ between templates
+	tObjects, _, err := z.TriggerGet(TriggerGetParams{
+		TemplateIDs:      []int{templateID},
+		ExpandExpression: true,
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("get template triggers error: %v", err)
+	}
+
+	items := make(map[string]ItemObject)
+	for _, i := range iObjects {
+		items[i.Key] = i
+	}
+
+	// Both `{host:key.func()}` and `func(/host/key)` reference formats
+	r := strings.NewReplacer("{"+host+":", "{:", "/"+host+"/", "//")
+
+	triggers := make(map[string]TriggerObject)
+	for _, t := range tObjects {
+		t.Expression = r.Replace(t.Expression)
+		t.RecoveryExpression = r.Replace(t.RecoveryExpression)
+		triggers[t.Description] = t
+	}
+
+	return items, triggers, nil
+}
@@ -1,6 +1,8 @@
 package zabbix
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 )
@@ -104,3 +106,147 @@ func testTemplateGet(t *testing.T, z Context, tCreatedIDs, hgCreatedIDs []int) [
 
 	return tObjects
 }
+
+func TestTemplateDiff(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p struct {
+			TemplateIDs      []int `json:"templateids"`
+			ExpandExpression bool  `json:"expandExpression"`
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		switch method {
+		case "template.get":
+
+			return []map[string]interface{}{
+				{"templateid": "1", "host": "Template A"},
+				{"templateid": "2", "host": "Template B"},
+			}, nil
+		case "item.get":
+
+			items := []map[string]interface{}{
+				{"itemid": "11", "key_": "agent.ping", "name": "Agent ping", "delay": "1m"},
+				{"itemid": "12", "key_": "system.uptime", "name": "Uptime", "delay": "1h"},
+			}
+			if p.TemplateIDs[0] == 2 {
+				items = append(items, map[string]interface{}{"itemid": "23", "key_": "system.cpu.load", "name": "CPU load", "delay": "1m"})
+			}
+
+			return items, nil
+		case "trigger.get":
+
+			if p.ExpandExpression == false {
+				return nil, fmt.Errorf("trigger expressions are not expanded")
+			}
+
+			if p.TemplateIDs[0] == 1 {
+				return []map[string]interface{}{
+					{"triggerid": "101", "description": "Agent unavailable", "expression": "nodata(/Template A/agent.ping,5m)=1", "priority": "3"},
+					{"triggerid": "102", "description": "Host restarted", "expression": "last(/Template A/system.uptime)<10m", "priority": "1"},
+				}, nil
+			}
+
+			return []map[string]interface{}{
+				{"triggerid": "201", "description": "Agent unavailable", "expression": "nodata(/Template B/agent.ping,5m)=1", "priority": "3"},
+				{"triggerid": "202", "description": "Host restarted", "expression": "last(/Template B/system.uptime)<10m", "priority": "2"},
+			}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	d, err := z.DiffTemplates(1, 2)
+	if err != nil {
+		t.Fatal("Template diff error:", err)
+	}
+
+	expected := TemplateDiff{
+		ItemsAdded:      []string{"system.cpu.load"},
+		ItemsRemoved:    []string{},
+		ItemsChanged:    []string{},
+		TriggersAdded:   []string{},
+		TriggersRemoved: []string{},
+		TriggersChanged: []string{"Host restarted"},
+	}
+
+	if reflect.DeepEqual(d, expected) == false {
+		t.Errorf("Template diff error: unexpected result %+v", d)
+	}
+
+	if _, err := z.DiffTemplates(1, 3); err == nil {
+		t.Error("Template diff error: expected error for missing template")
+	}
+
+	t.Logf("Template diff: success")
+}
+
+func TestTemplateWouldCreateCycle(t *testing.T) {
+
+	// Template ID to IDs of its parent templates (templates linked to it)
+	links := map[int][]int{
+		10001: {10002},
+		10002: {10003},
+		10003: {},
+		10004: {},
+	}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "template.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		var p TemplateGetParams
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		r := []map[string]interface{}{}
+		for _, id := range p.TemplateIDs {
+
+			parents := []map[string]interface{}{}
+			for _, l := range links[id] {
+				parents = append(parents, map[string]interface{}{"templateid": l})
+			}
+
+			r = append(r, map[string]interface{}{"templateid": id, "parentTemplates": parents})
+		}
+
+		return r, nil
+	})
+	defer closeMock()
+
+	tests := []struct {
+		name           string
+		templateID     int
+		linkTemplateID int
+		cycle          bool
+	}{
+		{name: "nested cycle", templateID: 10003, linkTemplateID: 10001, cycle: true},
+		{name: "direct cycle", templateID: 10002, linkTemplateID: 10001, cycle: true},
+		{name: "self link", templateID: 10004, linkTemplateID: 10004, cycle: true},
+		{name: "safe link", templateID: 10004, linkTemplateID: 10001, cycle: false},
+		{name: "already linked", templateID: 10001, linkTemplateID: 10003, cycle: false},
+	}
+
+	for _, tt := range tests {
+
+		cycle, err := z.WouldCreateTemplateCycle(tt.templateID, tt.linkTemplateID)
+		if err != nil {
+			t.Fatalf("Template would create cycle error (%s): %v", tt.name, err)
+		}
+
+		if cycle != tt.cycle {
+			t.Errorf("Template would create cycle error (%s): expected %v, got %v", tt.name, tt.cycle, cycle)
+		}
+	}
+
+	t.Logf("Template would create cycle: success")
+}
@@ -0,0 +1,812 @@
+package zabbix
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// For `TriggerObject` field: `Flags`
+const (
+	TriggerFlagsPlain      = 0
+	TriggerFlagsDiscovered = 4
+)
+
+// For `TriggerObject` field: `Priority`
+const (
+	TriggerPriorityNotClassified = 0
+	TriggerPriorityInformation   = 1
+	TriggerPriorityWarning       = 2
+	TriggerPriorityAverage       = 3
+	TriggerPriorityHigh          = 4
+	TriggerPriorityDisaster      = 5
+)
+
+// For `TriggerObject` field: `Status`
+const (
+	TriggerStatusEnabled  = 0
+	TriggerStatusDisabled = 1
+)
+
+// For `TriggerObject` field: `Type`
+const (
+	TriggerTypeSingle   = 0
+	TriggerTypeMultiple = 1
+)
+
+// TriggerValue is used for `TriggerObject` field: `Value`
+type TriggerValue int
+
+// For `TriggerObject` field: `Value`
+const (
+	TriggerValueOK      TriggerValue = 0
+	TriggerValueProblem TriggerValue = 1
+)
+
+// TriggerState is used for `TriggerObject` field: `State`
+type TriggerState int
+
+// For `TriggerObject` field: `State`
+const (
+	TriggerStateNormal  TriggerState = 0
+	TriggerStateUnknown TriggerState = 1
+)
+
+// For `TriggerObject` field: `RecoveryMode`
+const (
+	TriggerRecoveryModeExpression         = 0
+	TriggerRecoveryModeRecoveryExpression = 1
+	TriggerRecoveryModeNone               = 2
+)
+
+// For `TriggerObject` field: `ManualClose`
+const (
+	TriggerManualCloseNotAllowed = 0
+	TriggerManualCloseAllowed    = 1
+)
+
+// For `TriggerGetParams` field: `Evaltype`
+const (
+	TriggerEvaltypeAndOr = 0
+	TriggerEvaltypeOr    = 2
+)
+
+// For `TriggerTagObject` field: `Operator`
+const (
+	TriggerTagOperatorContains = 0
+	TriggerTagOperatorEquals   = 1
+)
+
+// TriggerObject struct is used to store trigger operations results
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/trigger/object
+type TriggerObject struct {
+	TriggerID          int          `json:"triggerid,omitempty"`
+	Description        string       `json:"description,omitempty"`
+	Comments           string       `json:"comments,omitempty"` // contains resolved macros if requested with `ExpandComment`
+	Expression         string       `json:"expression,omitempty"`
+	Flags              int          `json:"flags,omitempty"` // has defined consts, see above
+	LastChange         int          `json:"lastchange,omitempty"`
+	Priority           int          `json:"priority,omitempty"` // has defined consts, see above
+	Status             int          `json:"status,omitempty"`   // has defined consts, see above
+	TemplateID         int          `json:"templateid,omitempty"`
+	Type               int          `json:"type,omitempty"` // has defined consts, see above
+	URL                string       `json:"url,omitempty"`
+	Value              TriggerValue `json:"value,omitempty"`         // has defined consts, see above
+	RecoveryMode       int          `json:"recovery_mode,omitempty"` // has defined consts, see above
+	RecoveryExpression string       `json:"recovery_expression,omitempty"`
+	State              TriggerState `json:"state,omitempty"` // has defined consts, see above
+	Error              string       `json:"error,omitempty"`
+	ManualClose        int          `json:"manual_close,omitempty"` // has defined consts, see above
+
+	Dependencies []TriggerObject    `json:"dependencies,omitempty"`
+	Groups       []HostgroupObject  `json:"groups,omitempty"`
+	Hosts        []HostObject       `json:"hosts,omitempty"`
+	Items        []ItemObject       `json:"items,omitempty"`
+	Tags         []TriggerTagObject `json:"tags,omitempty"`
+}
+
+// TriggerTagObject struct is used to store trigger tag
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/trigger/object#trigger_tag
+type TriggerTagObject struct {
+	Tag   string `json:"tag,omitempty"`
+	Value string `json:"value,omitempty"`
+
+	Operator int `json:"operator,omitempty"` // Used for `get` operations, has defined consts, see above
+}
+
+// TriggerGetParams struct is used for trigger get requests
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/trigger/get#parameters
+type TriggerGetParams struct {
+	GetParameters
+
+	TriggerIDs     []int `json:"triggerids,omitempty"`
+	GroupIDs       []int `json:"groupids,omitempty"`
+	TemplateIDs    []int `json:"templateids,omitempty"`
+	HostIDs        []int `json:"hostids,omitempty"`
+	ItemIDs        []int `json:"itemids,omitempty"`
+	ApplicationIDs []int `json:"applicationids,omitempty"`
+
+	Functions                   []string           `json:"functions,omitempty"`
+	Group                       string             `json:"group,omitempty"`
+	Host                        string             `json:"host,omitempty"`
+	Inherited                   bool               `json:"inherited,omitempty"`
+	Templated                   bool               `json:"templated,omitempty"`
+	Dependent                   bool               `json:"dependent,omitempty"`
+	Monitored                   bool               `json:"monitored,omitempty"`
+	Active                      bool               `json:"active,omitempty"`
+	Maintenance                 bool               `json:"maintenance,omitempty"`
+	WithUnacknowledgedEvents    bool               `json:"withUnacknowledgedEvents,omitempty"`
+	WithAcknowledgedEvents      bool               `json:"withAcknowledgedEvents,omitempty"`
+	WithLastEventUnacknowledged bool               `json:"withLastEventUnacknowledged,omitempty"`
+	SkipDependent               bool               `json:"skipDependent,omitempty"`
+	LastChangeSince             int                `json:"lastChangeSince,omitempty"`
+	LastChangeTill              int                `json:"lastChangeTill,omitempty"`
+	OnlyTrue                    bool               `json:"only_true,omitempty"`
+	MinSeverity                 int                `json:"min_severity,omitempty"`
+	Evaltype                    int                `json:"evaltype,omitempty"` // has defined consts, see above
+	Tags                        []TriggerTagObject `json:"tags,omitempty"`
+	ExpandComment               bool               `json:"expandComment,omitempty"`
+	ExpandDescription           bool               `json:"expandDescription,omitempty"`
+	ExpandExpression            bool               `json:"expandExpression,omitempty"`
+
+	SelectGroups SelectQuery `json:"selectGroups,omitempty"`
+	SelectHosts  SelectQuery `json:"selectHosts,omitempty"`
+	SelectItems  SelectQuery `json:"selectItems,omitempty"`
+	// SelectFunctions        SelectQuery `json:"selectFunctions,omitempty"` // not implemented yet
+	SelectDependencies SelectQuery `json:"selectDependencies,omitempty"`
+	// SelectDiscoveryRule    SelectQuery `json:"selectDiscoveryRule,omitempty"` // not implemented yet
+	// SelectLastEvent        SelectQuery `json:"selectLastEvent,omitempty"` // not implemented yet
+	SelectTags SelectQuery `json:"selectTags,omitempty"`
+	// SelectTriggerDiscovery SelectQuery `json:"selectTriggerDiscovery,omitempty"` // not implemented yet
+}
+
+// Item references within expanded trigger expressions: `func(/host/key,...)` (since Zabbix 5.4)
+// and `{host:key.func(...)}` (before Zabbix 5.4). Reference of the new format is always the first
+// function argument, so it is anchored to the opening parenthesis to not match paths within
+// key parameters of the legacy format (e.g. `{host:vfs.file.size[/var/log/x].last()}`)
+var (
+	triggerExpressionItemRegexp       = regexp.MustCompile(`\(\s*/([^/,()]+)/([A-Za-z0-9_.\-]+(?:\[[^\]]*\])?)`)
+	triggerExpressionItemLegacyRegexp = regexp.MustCompile(`\{([^:{}]+):([^{}]+)\.[A-Za-z]+\([^{}]*\)\}`)
+)
+
+// Structure to store creation result
+type triggerCreateResult struct {
+	TriggerIDs []int `json:"triggerids"`
+}
+
+// Structure to store updation result
+type triggerUpdateResult struct {
+	TriggerIDs []int `json:"triggerids"`
+}
+
+// String returns text representation of the trigger value
+func (v TriggerValue) String() string {
+
+	switch v {
+	case TriggerValueOK:
+		return "OK"
+	case TriggerValueProblem:
+		return "Problem"
+	}
+
+	return fmt.Sprintf("TriggerValue(%d)", int(v))
+}
+
+// String returns text representation of the trigger state
+func (s TriggerState) String() string {
+
+	switch s {
+	case TriggerStateNormal:
+		return "Normal"
+	case TriggerStateUnknown:
+		return "Unknown"
+	}
+
+	return fmt.Sprintf("TriggerState(%d)", int(s))
+}
+
+// InProblem checks the trigger is in problem state.
+// Note that value of the trigger in unknown state (see `IsUnknown()`) is the last known one
+func (t *TriggerObject) InProblem() bool {
+
+	return t.Value == TriggerValueProblem
+}
+
+// IsUnknown checks the trigger state is unknown (e.g. trigger items are not supported),
+// the reason is available in `Error` field
+func (t *TriggerObject) IsUnknown() bool {
+
+	return t.State == TriggerStateUnknown
+}
+
+// TriggerGet gets triggers
+func (z *Context) TriggerGet(params TriggerGetParams) ([]TriggerObject, int, error) {
+
+	var result []TriggerObject
+
+	status, err := z.request("trigger.get", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result, status, nil
+}
+
+// TriggerCreate creates triggers
+func (z *Context) TriggerCreate(params []TriggerObject) ([]int, int, error) {
+
+	var result triggerCreateResult
+
+	status, err := z.request("trigger.create", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.TriggerIDs, status, nil
+}
+
+// TriggerUpdate updates triggers
+func (z *Context) TriggerUpdate(params []TriggerObject) ([]int, int, error) {
+
+	var result triggerUpdateResult
+
+	status, err := z.request("trigger.update", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result.TriggerIDs, status, nil
+}
+
+// SetTriggerPriorities sets the `priority` (see `TriggerPriority*` consts) for triggers with IDs `ids`.
+// Note that triggers inherited from templates can not be changed on hosts, Zabbix API error
+// is returned in this case
+func (z *Context) SetTriggerPriorities(ids []int, priority int) ([]int, error) {
+
+	var result triggerUpdateResult
+
+	if priority < TriggerPriorityNotClassified || priority > TriggerPriorityDisaster {
+		return nil, fmt.Errorf("invalid trigger priority %d, must be within 0-5", priority)
+	}
+
+	var params []map[string]interface{}
+
+	// Maps are used instead of `TriggerObject` to send zero priority
+	for _, id := range ids {
+		params = append(params, map[string]interface{}{
+			"triggerid": id,
+			"priority":  priority,
+		})
+	}
+
+	if _, err := z.request("trigger.update", params, &result); err != nil {
+		return nil, fmt.Errorf("trigger priorities update error: %v", err)
+	}
+
+	return result.TriggerIDs, nil
+}
+
+// GetActiveTriggersRespectingDeps gets enabled triggers of monitored hosts in `groupIDs` which are
+// in problem state, skipping triggers suppressed by dependencies. As Zabbix does, a trigger is
+// considered suppressed if any trigger it depends on is in problem state as well
+func (z *Context) GetActiveTriggersRespectingDeps(groupIDs []int) ([]TriggerObject, error) {
+
+	tObjects, _, err := z.TriggerGet(TriggerGetParams{
+		GroupIDs:           groupIDs,
+		Monitored:          true,
+		SelectDependencies: SelectFields{"triggerid", "value"},
+		GetParameters: GetParameters{
+			Filter: map[string]interface{}{
+				"value": TriggerValueProblem,
+			},
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := []TriggerObject{}
+
+	for _, t := range tObjects {
+
+		suppressed := false
+
+		for _, d := range t.Dependencies {
+			if d.InProblem() == true {
+				suppressed = true
+				break
+			}
+		}
+
+		if suppressed == false {
+			r = append(r, t)
+		}
+	}
+
+	return r, nil
+}
+
+// ReplaceTriggerDependencies sets the triggers the trigger with `triggerID` depends on. Note that the whole
+// set of dependencies is replaced: dependencies not listed in `dependsOn` are removed (empty `dependsOn`
+// removes all dependencies). Use `AddTriggerDependencies()` to add dependencies keeping existing ones.
+// Triggers in `dependsOn` are checked to exist before update
+func (z *Context) ReplaceTriggerDependencies(triggerID int, dependsOn []int) error {
+
+	if err := z.triggerDependenciesValidate(triggerID, dependsOn); err != nil {
+		return err
+	}
+
+	deps := []map[string]interface{}{}
+	for _, d := range dependsOn {
+		deps = append(deps, map[string]interface{}{"triggerid": d})
+	}
+
+	// Map is used instead of `TriggerObject` to be able to send empty dependencies
+	if _, err := z.request("trigger.update", map[string]interface{}{
+		"triggerid":    triggerID,
+		"dependencies": deps,
+	}, &triggerUpdateResult{}); err != nil {
+		return fmt.Errorf("trigger dependencies update error: %v", err)
+	}
+
+	return nil
+}
+
+// AddTriggerDependencies adds dependencies of the trigger with `triggerID` on triggers `dependsOn`,
+// existing dependencies are kept. Triggers in `dependsOn` are checked to exist before update
+func (z *Context) AddTriggerDependencies(triggerID int, dependsOn []int) error {
+
+	if len(dependsOn) == 0 {
+		return nil
+	}
+
+	if err := z.triggerDependenciesValidate(triggerID, dependsOn); err != nil {
+		return err
+	}
+
+	var params []map[string]interface{}
+	for _, d := range dependsOn {
+		params = append(params, map[string]interface{}{
+			"triggerid":          triggerID,
+			"dependsOnTriggerid": d,
+		})
+	}
+
+	if _, err := z.request("trigger.adddependencies", params, &triggerUpdateResult{}); err != nil {
+		return fmt.Errorf("trigger dependencies add error: %v", err)
+	}
+
+	return nil
+}
+
+// triggerDependenciesValidate checks the trigger does not depend on itself and all triggers
+// it depends on exist
+func (z *Context) triggerDependenciesValidate(triggerID int, dependsOn []int) error {
+
+	if len(dependsOn) == 0 {
+		return nil
+	}
+
+	if containsInt(dependsOn, triggerID) == true {
+		return fmt.Errorf("trigger %d can not depend on itself", triggerID)
+	}
+
+	tObjects, _, err := z.TriggerGet(TriggerGetParams{
+		TriggerIDs: dependsOn,
+		GetParameters: GetParameters{
+			Output: SelectFields{"triggerid"},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("get dependency triggers error: %v", err)
+	}
+
+	found := make(map[int]bool)
+	for _, t := range tObjects {
+		found[t.TriggerID] = true
+	}
+
+	for _, d := range dependsOn {
+		if found[d] == false {
+			return fmt.Errorf("trigger with id %d not found", d)
+		}
+	}
+
+	return nil
+}
+
+// GetTriggerDependencyGraph builds the dependency graph for the specified triggers.
+// Dependencies are expanded recursively, so the result contains the specified triggers and
+// all triggers they depend on (directly or indirectly). Result is an adjacency map of
+// trigger ID to IDs of the triggers it depends on.
+//
+// Zabbix does not allow circular dependencies, but if a cycle is found an error is returned.
+func (z *Context) GetTriggerDependencyGraph(triggerIDs []int) (map[int][]int, error) {
+
+	graph := make(map[int][]int)
+
+	queue := triggerIDs
+
+	for len(queue) > 0 {
+
+		tObjects, _, err := z.TriggerGet(TriggerGetParams{
+			TriggerIDs:         queue,
+			SelectDependencies: SelectFields{"triggerid"},
+			GetParameters: GetParameters{
+				Output: SelectFields{"triggerid"},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		queue = nil
+
+		for _, t := range tObjects {
+
+			deps := []int{}
+
+			for _, d := range t.Dependencies {
+				deps = append(deps, d.TriggerID)
+			}
+
+			graph[t.TriggerID] = deps
+		}
+
+		for _, t := range tObjects {
+			for _, d := range graph[t.TriggerID] {
+				if _, b := graph[d]; b == false && containsInt(queue, d) == false {
+					queue = append(queue, d)
+				}
+			}
+		}
+	}
+
+	if cycle := triggerDependencyCycle(graph); cycle != nil {
+		return nil, fmt.Errorf("trigger dependencies cycle detected: %v", cycle)
+	}
+
+	return graph, nil
+}
+
+// triggerDependencyCycle looks for a cycle in dependency graph and returns
+// the trigger IDs forming it (or nil if graph has no cycles)
+func triggerDependencyCycle(graph map[int][]int) []int {
+
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+
+	var (
+		path  []int
+		cycle []int
+		visit func(id int) bool
+	)
+
+	state := make(map[int]int)
+
+	visit = func(id int) bool {
+
+		state[id] = inProgress
+		path = append(path, id)
+
+		for _, d := range graph[id] {
+			switch state[d] {
+			case inProgress:
+				for i, p := range path {
+					if p == d {
+						cycle = append(append([]int{}, path[i:]...), d)
+						break
+					}
+				}
+				return true
+			case unvisited:
+				if visit(d) == true {
+					return true
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[id] = done
+
+		return false
+	}
+
+	// Iterate over sorted IDs to get stable results
+	var ids []int
+	for id := range graph {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	for _, id := range ids {
+		if state[id] == unvisited && visit(id) == true {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+// TriggerItemIDs returns IDs of items referenced in the trigger expression. Items are requested with
+// `selectItems`, if no items are returned, the expanded expression is parsed and referenced items
+// are resolved by host and key
+func (z *Context) TriggerItemIDs(triggerID int) ([]int, error) {
+
+	tObjects, _, err := z.TriggerGet(TriggerGetParams{
+		TriggerIDs:       []int{triggerID},
+		ExpandExpression: true,
+		SelectItems:      SelectFields{"itemid"},
+		GetParameters: GetParameters{
+			Output: SelectFields{"triggerid", "expression"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tObjects) == 0 {
+		return nil, fmt.Errorf("trigger with id %d not found", triggerID)
+	}
+
+	r := []int{}
+
+	for _, i := range tObjects[0].Items {
+		r = append(r, i.ItemID)
+	}
+
+	if len(r) > 0 {
+		sort.Ints(r)
+		return r, nil
+	}
+
+	// Fallback to expression parsing
+	for host, keys := range triggerExpressionItems(tObjects[0].Expression) {
+
+		iObjects, _, err := z.ItemGet(ItemGetParams{
+			Host: host,
+			GetParameters: GetParameters{
+				Filter: map[string]interface{}{
+					"key_": keys,
+				},
+				Output: SelectFields{"itemid", "key_"},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("resolve items error: %v", err)
+		}
+
+		if len(iObjects) != len(keys) {
+			return nil, fmt.Errorf("unable to resolve all items of host `%s` referenced in trigger %d expression", host, triggerID)
+		}
+
+		for _, i := range iObjects {
+			r = append(r, i.ItemID)
+		}
+	}
+
+	sort.Ints(r)
+
+	return r, nil
+}
+
+// triggerExpressionItems parses expanded trigger expression and returns the item keys
+// referenced in it. Result is a map of host to unique item keys
+func triggerExpressionItems(expression string) map[string][]string {
+
+	r := make(map[string][]string)
+
+	add := func(host, key string) {
+		for _, k := range r[host] {
+			if k == key {
+				return
+			}
+		}
+		r[host] = append(r[host], key)
+	}
+
+	for _, m := range triggerExpressionItemRegexp.FindAllStringSubmatch(expression, -1) {
+		add(m[1], m[2])
+	}
+
+	for _, m := range triggerExpressionItemLegacyRegexp.FindAllStringSubmatch(expression, -1) {
+		add(m[1], m[2])
+	}
+
+	return r
+}
+
+// CreateHostTriggerFromTemplate creates a host trigger (not linked to the template) copying the template
+// trigger and returns its ID. Item references to the template in trigger expressions are replaced
+// with references to the host, so the host must have items with the same keys. Dependencies on triggers
+// of the same template are replaced with dependencies on the host triggers with the same descriptions,
+// so such triggers must already exist on the host
+func (z *Context) CreateHostTriggerFromTemplate(templateTriggerID, hostID int) (int, error) {
+
+	tObjects, _, err := z.TriggerGet(TriggerGetParams{
+		TriggerIDs:         []int{templateTriggerID},
+		ExpandExpression:   true,
+		SelectHosts:        SelectFields{"hostid", "host"},
+		SelectTags:         SelectExtendedOutput,
+		SelectDependencies: SelectFields{"triggerid", "description"},
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("get template trigger error: %v", err)
+	}
+
+	if len(tObjects) == 0 {
+		return 0, fmt.Errorf("trigger with id %d not found", templateTriggerID)
+	}
+
+	t := tObjects[0]
+
+	if len(t.Hosts) != 1 {
+		return 0, fmt.Errorf("trigger with id %d must belong to exactly one template", templateTriggerID)
+	}
+
+	hObjects, _, err := z.HostGet(HostGetParams{
+		HostIDs: []int{hostID},
+		GetParameters: GetParameters{
+			Output: SelectFields{"hostid", "host"},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("get host error: %v", err)
+	}
+
+	if len(hObjects) == 0 {
+		return 0, fmt.Errorf("host with id %d not found", hostID)
+	}
+
+	from, to := t.Hosts[0].Host, hObjects[0].Host
+
+	var tags []TriggerTagObject
+	for _, tag := range t.Tags {
+		tags = append(tags, TriggerTagObject{
+			Tag:   tag.Tag,
+			Value: tag.Value,
+		})
+	}
+
+	deps, err := z.triggerDependenciesRemap(t.Dependencies, t.Hosts[0].HostID, hostID)
+	if err != nil {
+		return 0, err
+	}
+
+	tCreatedIDs, _, err := z.TriggerCreate([]TriggerObject{
+		{
+			Description:        t.Description,
+			Comments:           t.Comments,
+			ManualClose:        t.ManualClose,
+			Dependencies:       deps,
+			Expression:         triggerExpressionRemap(t.Expression, from, to),
+			Priority:           t.Priority,
+			Status:             t.Status,
+			Type:               t.Type,
+			URL:                t.URL,
+			RecoveryMode:       t.RecoveryMode,
+			RecoveryExpression: triggerExpressionRemap(t.RecoveryExpression, from, to),
+			Tags:               tags,
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("create host trigger error: %v", err)
+	}
+
+	if len(tCreatedIDs) == 0 {
+		return 0, fmt.Errorf("create host trigger error: empty IDs array")
+	}
+
+	return tCreatedIDs[0], nil
+}
+
+// triggerDependenciesRemap replaces dependencies on triggers of the template with `templateID` with
+// dependencies on triggers of the host with `hostID` having the same descriptions. Other dependencies
+// are kept as is
+func (z *Context) triggerDependenciesRemap(deps []TriggerObject, templateID, hostID int) ([]TriggerObject, error) {
+
+	if len(deps) == 0 {
+		return nil, nil
+	}
+
+	var ids []int
+	for _, d := range deps {
+		ids = append(ids, d.TriggerID)
+	}
+
+	tObjects, _, err := z.TriggerGet(TriggerGetParams{
+		TriggerIDs:  ids,
+		TemplateIDs: []int{templateID},
+		GetParameters: GetParameters{
+			Output: SelectFields{"triggerid", "description"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get trigger dependencies error: %v", err)
+	}
+
+	templated := make(map[int]string)
+	for _, t := range tObjects {
+		templated[t.TriggerID] = t.Description
+	}
+
+	hostTriggers := make(map[string]int)
+
+	if len(templated) > 0 {
+
+		var descriptions []string
+		for _, d := range templated {
+			descriptions = append(descriptions, d)
+		}
+
+		tObjects, _, err := z.TriggerGet(TriggerGetParams{
+			HostIDs: []int{hostID},
+			GetParameters: GetParameters{
+				Filter: map[string]interface{}{
+					"description": descriptions,
+				},
+				Output: SelectFields{"triggerid", "description"},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get host triggers error: %v", err)
+		}
+
+		for _, t := range tObjects {
+			hostTriggers[t.Description] = t.TriggerID
+		}
+	}
+
+	var r []TriggerObject
+
+	for _, d := range deps {
+
+		id := d.TriggerID
+
+		if desc, b := templated[id]; b == true {
+			if id, b = hostTriggers[desc]; b == false {
+				return nil, fmt.Errorf("trigger `%s` the trigger depends on not found on host with id %d", desc, hostID)
+			}
+		}
+
+		r = append(r, TriggerObject{TriggerID: id})
+	}
+
+	return r, nil
+}
+
+// triggerExpressionRemap replaces references to items of the host `from` with references to items
+// with the same keys of the host `to` in the expanded trigger expression. Both `func(/host/key)` and
+// `{host:key.func()}` reference formats are supported
+func triggerExpressionRemap(expression, from, to string) string {
+
+	expression = triggerExpressionItemRegexp.ReplaceAllStringFunc(expression, func(m string) string {
+
+		// Match starts with the function opening parenthesis
+		i := strings.Index(m, "/")
+		prefix, ref := m[:i], m[i:]
+
+		if strings.HasPrefix(ref, "/"+from+"/") == false {
+			return m
+		}
+		return prefix + "/" + to + "/" + strings.TrimPrefix(ref, "/"+from+"/")
+	})
+
+	return triggerExpressionItemLegacyRegexp.ReplaceAllStringFunc(expression, func(m string) string {
+		if strings.HasPrefix(m, "{"+from+":") == false {
+			return m
+		}
+		return "{" + to + ":" + strings.TrimPrefix(m, "{"+from+":")
+	})
+}
@@ -0,0 +1,629 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestTriggerDependencyGraph(t *testing.T) {
+
+	// 1 -> 2 -> 3, 1 -> 3
+	deps := map[int][]int{
+		1: {2, 3},
+		2: {3},
+		3: {},
+	}
+
+	z, closeMock := testTriggerDependenciesMock(t, deps)
+	defer closeMock()
+
+	graph, err := z.GetTriggerDependencyGraph([]int{1})
+	if err != nil {
+		t.Fatal("Trigger dependency graph error:", err)
+	}
+
+	if reflect.DeepEqual(graph, deps) == false {
+		t.Errorf("Trigger dependency graph error: unexpected graph %v", graph)
+	}
+
+	// 1 -> 2 -> 1
+	zCycle, closeMockCycle := testTriggerDependenciesMock(t, map[int][]int{
+		1: {2},
+		2: {1},
+	})
+	defer closeMockCycle()
+
+	if _, err := zCycle.GetTriggerDependencyGraph([]int{1}); err == nil {
+		t.Error("Trigger dependency graph error: cycle not detected")
+	}
+
+	t.Logf("Trigger dependency graph: success")
+}
+
+func testTriggerDependenciesMock(t *testing.T, deps map[int][]int) (*Context, func()) {
+
+	return testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p TriggerGetParams
+
+		if method != "trigger.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		r := []map[string]interface{}{}
+
+		for _, id := range p.TriggerIDs {
+
+			d := []map[string]interface{}{}
+			for _, e := range deps[id] {
+				d = append(d, map[string]interface{}{"triggerid": e})
+			}
+
+			r = append(r, map[string]interface{}{"triggerid": id, "dependencies": d})
+		}
+
+		return r, nil
+	})
+}
+
+func TestTriggerSetPriorities(t *testing.T) {
+
+	var sent []map[string]interface{}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "trigger.update" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		if err := json.Unmarshal(params, &sent); err != nil {
+			return nil, err
+		}
+
+		for _, p := range sent {
+			if p["triggerid"] == float64(3) {
+				return nil, fmt.Errorf("Cannot update templated trigger.")
+			}
+		}
+
+		return map[string]interface{}{
+			"triggerids": []string{"1", "2"},
+		}, nil
+	})
+	defer closeMock()
+
+	tUpdatedIDs, err := z.SetTriggerPriorities([]int{1, 2}, TriggerPriorityNotClassified)
+	if err != nil {
+		t.Fatal("Trigger set priorities error:", err)
+	}
+
+	if reflect.DeepEqual(tUpdatedIDs, []int{1, 2}) == false {
+		t.Errorf("Trigger set priorities error: unexpected IDs %v", tUpdatedIDs)
+	}
+
+	for _, p := range sent {
+		if v, b := p["priority"]; b == false || v != float64(TriggerPriorityNotClassified) {
+			t.Errorf("Trigger set priorities error: unexpected payload %v", sent)
+		}
+	}
+
+	// Priority out of range
+	if _, err := z.SetTriggerPriorities([]int{1}, 6); err == nil {
+		t.Error("Trigger set priorities error: invalid priority accepted")
+	}
+
+	// Templated trigger
+	if _, err := z.SetTriggerPriorities([]int{3}, TriggerPriorityHigh); err == nil {
+		t.Error("Trigger set priorities error: Zabbix API error is not returned")
+	}
+
+	t.Logf("Trigger set priorities: success")
+}
+
+func TestTriggerValueState(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "trigger.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		return []map[string]interface{}{
+			{"triggerid": "1", "value": "0", "state": "0", "error": ""},
+			{"triggerid": "2", "value": "1", "state": "0", "error": ""},
+			{"triggerid": "3", "value": "0", "state": "1", "error": "Cannot evaluate expression"},
+		}, nil
+	})
+	defer closeMock()
+
+	tObjects, _, err := z.TriggerGet(TriggerGetParams{
+		GetParameters: GetParameters{
+			Output: SelectFields{"triggerid", "value", "state", "error"},
+		},
+	})
+	if err != nil {
+		t.Fatal("Trigger value state error:", err)
+	}
+
+	if len(tObjects) != 3 {
+		t.Fatalf("Trigger value state error: unexpected result %v", tObjects)
+	}
+
+	tests := []struct {
+		name      string
+		inProblem bool
+		isUnknown bool
+		value     string
+		state     string
+	}{
+		{
+			name:      "ok",
+			inProblem: false,
+			isUnknown: false,
+			value:     "OK",
+			state:     "Normal",
+		},
+		{
+			name:      "problem",
+			inProblem: true,
+			isUnknown: false,
+			value:     "Problem",
+			state:     "Normal",
+		},
+		{
+			name:      "unknown",
+			inProblem: false,
+			isUnknown: true,
+			value:     "OK",
+			state:     "Unknown",
+		},
+	}
+
+	for i, tt := range tests {
+
+		o := tObjects[i]
+
+		if o.InProblem() != tt.inProblem || o.IsUnknown() != tt.isUnknown {
+			t.Errorf("Trigger value state error (%s): unexpected helpers result %v, %v", tt.name, o.InProblem(), o.IsUnknown())
+		}
+
+		if o.Value.String() != tt.value || o.State.String() != tt.state {
+			t.Errorf("Trigger value state error (%s): unexpected strings %s, %s", tt.name, o.Value, o.State)
+		}
+	}
+
+	if tObjects[2].Error == "" {
+		t.Error("Trigger value state error: empty error for trigger in unknown state")
+	}
+
+	t.Logf("Trigger value state: success")
+}
+
+func TestTriggerActiveRespectingDeps(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "trigger.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		var p struct {
+			GroupIDs  []int                  `json:"groupids"`
+			Monitored bool                   `json:"monitored"`
+			Filter    map[string]interface{} `json:"filter"`
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		if len(p.GroupIDs) != 1 || p.GroupIDs[0] != 2 || p.Monitored == false || p.Filter["value"] != float64(1) {
+			return nil, fmt.Errorf("unexpected params %s", string(params))
+		}
+
+		return []map[string]interface{}{
+			{
+				"triggerid":    "10",
+				"description":  "Router is unreachable",
+				"value":        "1",
+				"dependencies": []interface{}{},
+			},
+			{
+				"triggerid":   "11",
+				"description": "Server behind router is unreachable",
+				"value":       "1",
+				"dependencies": []map[string]interface{}{
+					{"triggerid": "10", "value": "1"},
+				},
+			},
+			{
+				"triggerid":   "12",
+				"description": "Disk space is low",
+				"value":       "1",
+				"dependencies": []map[string]interface{}{
+					{"triggerid": "13", "value": "0"},
+				},
+			},
+		}, nil
+	})
+	defer closeMock()
+
+	tObjects, err := z.GetActiveTriggersRespectingDeps([]int{2})
+	if err != nil {
+		t.Fatal("Trigger active respecting deps error:", err)
+	}
+
+	var ids []int
+	for _, o := range tObjects {
+		ids = append(ids, o.TriggerID)
+	}
+
+	if len(ids) != 2 || ids[0] != 10 || ids[1] != 12 {
+		t.Fatalf("Trigger active respecting deps error: unexpected triggers %v", ids)
+	}
+
+	t.Logf("Trigger active respecting deps: success")
+}
+
+func TestTriggerExpandComment(t *testing.T) {
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "trigger.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		var p struct {
+			ExpandComment bool `json:"expandComment"`
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		comments := "Check {HOST.NAME} runbook: {$RUNBOOK.URL}"
+		if p.ExpandComment == true {
+			comments = "Check Server runbook: https://wiki.example.com/server"
+		}
+
+		return []map[string]interface{}{
+			{"triggerid": "1", "comments": comments},
+			{"triggerid": "2", "comments": ""},
+		}, nil
+	})
+	defer closeMock()
+
+	tests := []struct {
+		expand   bool
+		expected string
+	}{
+		{expand: false, expected: "Check {HOST.NAME} runbook: {$RUNBOOK.URL}"},
+		{expand: true, expected: "Check Server runbook: https://wiki.example.com/server"},
+	}
+
+	for _, tt := range tests {
+
+		tObjects, _, err := z.TriggerGet(TriggerGetParams{
+			ExpandComment: tt.expand,
+			GetParameters: GetParameters{
+				Output: SelectFields{"triggerid", "comments"},
+			},
+		})
+		if err != nil {
+			t.Fatal("Trigger expand comment error:", err)
+		}
+
+		if len(tObjects) != 2 || tObjects[0].Comments != tt.expected || tObjects[1].Comments != "" {
+			t.Errorf("Trigger expand comment error (expand: %v): unexpected triggers %v", tt.expand, tObjects)
+		}
+	}
+
+	t.Logf("Trigger expand comment: success")
+}
+
+func TestTriggerReplaceDependencies(t *testing.T) {
+
+	var (
+		updated map[string]interface{}
+		added   []map[string]interface{}
+	)
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "trigger.get":
+
+			var p TriggerGetParams
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			r := []map[string]interface{}{}
+			for _, id := range p.TriggerIDs {
+				if id < 100 {
+					r = append(r, map[string]interface{}{"triggerid": id})
+				}
+			}
+
+			return r, nil
+
+		case "trigger.update":
+
+			if err := json.Unmarshal(params, &updated); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"triggerids": []string{"1"}}, nil
+
+		case "trigger.adddependencies":
+
+			if err := json.Unmarshal(params, &added); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"triggerids": []string{"1"}}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	if err := z.ReplaceTriggerDependencies(1, []int{10, 11}); err != nil {
+		t.Fatal("Trigger replace dependencies error:", err)
+	}
+
+	expected := map[string]interface{}{
+		"triggerid": float64(1),
+		"dependencies": []interface{}{
+			map[string]interface{}{"triggerid": float64(10)},
+			map[string]interface{}{"triggerid": float64(11)},
+		},
+	}
+
+	if reflect.DeepEqual(updated, expected) == false {
+		t.Errorf("Trigger replace dependencies error: unexpected params %v", updated)
+	}
+
+	// All dependencies removal
+	if err := z.ReplaceTriggerDependencies(1, nil); err != nil {
+		t.Fatal("Trigger replace dependencies error:", err)
+	}
+
+	if deps, b := updated["dependencies"].([]interface{}); b == false || len(deps) != 0 {
+		t.Errorf("Trigger replace dependencies error: unexpected params %v", updated)
+	}
+
+	// Nonexistent and self dependencies
+	updated = nil
+
+	if err := z.ReplaceTriggerDependencies(1, []int{10, 150}); err == nil || updated != nil {
+		t.Errorf("Trigger replace dependencies error: expected error for nonexistent trigger, got %v", err)
+	}
+
+	if err := z.ReplaceTriggerDependencies(1, []int{1}); err == nil || updated != nil {
+		t.Errorf("Trigger replace dependencies error: expected error for self dependency, got %v", err)
+	}
+
+	// Additive change
+	if err := z.AddTriggerDependencies(1, []int{12}); err != nil {
+		t.Fatal("Trigger replace dependencies error:", err)
+	}
+
+	if len(added) != 1 || added[0]["triggerid"] != float64(1) || added[0]["dependsOnTriggerid"] != float64(12) {
+		t.Errorf("Trigger replace dependencies error: unexpected add params %v", added)
+	}
+
+	t.Logf("Trigger replace dependencies: success")
+}
+
+func TestTriggerItemIDs(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		items      []map[string]interface{}
+		expression string
+	}{
+		{
+			name:       "select items",
+			items:      []map[string]interface{}{{"itemid": "1001"}, {"itemid": "1002"}},
+			expression: "last(/web01/net.if.in[eth0])>100 or last(/web01/net.if.out[eth0])>100",
+		},
+		{
+			name:       "expression",
+			items:      []map[string]interface{}{},
+			expression: "last(/web01/net.if.in[eth0])>100 or last(/web01/net.if.out[eth0])>100",
+		},
+		{
+			name:       "legacy expression",
+			items:      []map[string]interface{}{},
+			expression: "{web01:net.if.in[eth0].last()}>100 or {web01:net.if.out[eth0].avg(5m)}>100",
+		},
+	}
+
+	for _, tt := range tests {
+
+		z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+			switch method {
+			case "trigger.get":
+				return []map[string]interface{}{
+					{"triggerid": "101", "expression": tt.expression, "items": tt.items},
+				}, nil
+			case "item.get":
+
+				var p ItemGetParams
+
+				if err := json.Unmarshal(params, &p); err != nil {
+					return nil, err
+				}
+
+				if p.Host != "web01" || reflect.DeepEqual(p.Filter["key_"], []interface{}{"net.if.in[eth0]", "net.if.out[eth0]"}) == false {
+					return nil, fmt.Errorf("unexpected params %s", string(params))
+				}
+
+				return []map[string]interface{}{
+					{"itemid": "1002", "key_": "net.if.out[eth0]"},
+					{"itemid": "1001", "key_": "net.if.in[eth0]"},
+				}, nil
+			}
+
+			return nil, fmt.Errorf("unexpected method %s", method)
+		})
+
+		ids, err := z.TriggerItemIDs(101)
+		closeMock()
+
+		if err != nil {
+			t.Fatalf("Trigger item IDs error (%s): %v", tt.name, err)
+		}
+
+		if reflect.DeepEqual(ids, []int{1001, 1002}) == false {
+			t.Errorf("Trigger item IDs error (%s): unexpected result %v", tt.name, ids)
+		}
+	}
+
+	t.Logf("Trigger item IDs: success")
+}
+
+func TestTriggerExpressionItems(t *testing.T) {
+
+	tests := []struct {
+		expression string
+		expected   map[string][]string
+	}{
+		{
+			expression: "{web01:vfs.file.size[/var/log/x].last()}>0",
+			expected:   map[string][]string{"web01": {"vfs.file.size[/var/log/x]"}},
+		},
+		{
+			expression: "last(/web01/vfs.file.size[/var/log/x])>0 and nodata( /web02/agent.ping,5m)=1",
+			expected:   map[string][]string{"web01": {"vfs.file.size[/var/log/x]"}, "web02": {"agent.ping"}},
+		},
+	}
+
+	for _, tt := range tests {
+		if r := triggerExpressionItems(tt.expression); reflect.DeepEqual(r, tt.expected) == false {
+			t.Errorf("Trigger expression items error (%s): unexpected result %v", tt.expression, r)
+		}
+	}
+
+	// Paths within legacy key parameters are kept on remap
+	if e := triggerExpressionRemap("{web01:vfs.file.size[/web01/x].last()}>0", "web01", "web02"); e != "{web02:vfs.file.size[/web01/x].last()}>0" {
+		t.Errorf("Trigger expression items error: unexpected remapped expression %s", e)
+	}
+
+	t.Logf("Trigger expression items: success")
+}
+
+func TestTriggerCreateFromTemplate(t *testing.T) {
+
+	var created []TriggerObject
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "trigger.get":
+
+			var p TriggerGetParams
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			// Dependencies on the template triggers
+			if reflect.DeepEqual(p.TemplateIDs, []int{10001}) == true {
+				if reflect.DeepEqual(p.TriggerIDs, []int{13002, 15000}) == false {
+					return nil, fmt.Errorf("unexpected params %s", string(params))
+				}
+				return []map[string]interface{}{{"triggerid": "13002", "description": "Agent unavailable"}}, nil
+			}
+
+			// Host triggers the copy depends on
+			if reflect.DeepEqual(p.HostIDs, []int{10084}) == true {
+				if reflect.DeepEqual(p.Filter["description"], []interface{}{"Agent unavailable"}) == false {
+					return nil, fmt.Errorf("unexpected params %s", string(params))
+				}
+				return []map[string]interface{}{{"triggerid": "14500", "description": "Agent unavailable"}}, nil
+			}
+
+			if p.ExpandExpression == false {
+				return nil, errors.New("expression is not expanded")
+			}
+
+			return []map[string]interface{}{
+				{
+					"triggerid":    "13001",
+					"description":  "High CPU load on {HOST.NAME}",
+					"comments":     "Check running processes",
+					"manual_close": "1",
+					"expression":   "avg(/Template OS Linux/system.cpu.load[all,avg1],5m)>5 and last(/Other host/agent.ping)=1",
+					"priority":     "4",
+					"hosts":        []map[string]interface{}{{"hostid": "10001", "host": "Template OS Linux"}},
+					"tags":         []map[string]interface{}{{"tag": "scope", "value": "performance"}},
+					"dependencies": []map[string]interface{}{
+						{"triggerid": "13002", "description": "Agent unavailable"},
+						{"triggerid": "15000", "description": "Router unreachable"},
+					},
+				},
+			}, nil
+		case "host.get":
+
+			return []map[string]interface{}{{"hostid": "10084", "host": "web-1"}}, nil
+		case "trigger.create":
+
+			if err := json.Unmarshal(params, &created); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"triggerids": []string{"14001"}}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	id, err := z.CreateHostTriggerFromTemplate(13001, 10084)
+	if err != nil {
+		t.Fatal("Trigger create from template error:", err)
+	}
+
+	if id != 14001 {
+		t.Errorf("Trigger create from template error: unexpected ID %d", id)
+	}
+
+	if len(created) != 1 {
+		t.Fatalf("Trigger create from template error: unexpected triggers %v", created)
+	}
+
+	if e := "avg(/web-1/system.cpu.load[all,avg1],5m)>5 and last(/Other host/agent.ping)=1"; created[0].Expression != e {
+		t.Errorf("Trigger create from template error: unexpected expression %s", created[0].Expression)
+	}
+
+	if created[0].Priority != 4 || created[0].Description != "High CPU load on {HOST.NAME}" || len(created[0].Tags) != 1 {
+		t.Errorf("Trigger create from template error: unexpected trigger %+v", created[0])
+	}
+
+	if created[0].Comments != "Check running processes" || created[0].ManualClose != TriggerManualCloseAllowed {
+		t.Errorf("Trigger create from template error: unexpected comments or manual close %+v", created[0])
+	}
+
+	// Dependency on the template trigger is replaced with the host one
+	if reflect.DeepEqual(created[0].Dependencies, []TriggerObject{{TriggerID: 14500}, {TriggerID: 15000}}) == false {
+		t.Errorf("Trigger create from template error: unexpected dependencies %+v", created[0].Dependencies)
+	}
+
+	// Legacy expression format
+	if e := triggerExpressionRemap("{Template OS Linux:system.cpu.load[all,avg1].avg(5m)}>5", "Template OS Linux", "web-1"); e != "{web-1:system.cpu.load[all,avg1].avg(5m)}>5" {
+		t.Errorf("Trigger create from template error: unexpected legacy expression %s", e)
+	}
+
+	t.Logf("Trigger create from template: success")
+}
@@ -1,5 +1,10 @@
 package zabbix
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // For `UserObject` field: `AutoLogin`
 const (
 	UserAutoLoginDisabled = 0
@@ -20,6 +25,12 @@ const (
 	UserTypeSuperAdmin = 3
 )
 
+// Type of the role granting super admin permissions (since Zabbix 5.2)
+const userRoleTypeSuperAdmin = 3
+
+// Zabbix API version user roles are introduced in
+const userRolesVersion = "5.2"
+
 // For `MediaObject` field: `Active`
 const (
 	MediaActiveEnabled  = 0
@@ -42,8 +53,9 @@ type UserObject struct {
 	Refresh       string `json:"refresh,omitempty"`
 	RowsPerPage   int    `json:"rows_per_page,omitempty"`
 	Surname       string `json:"surname,omitempty"`
-	Theme         string `json:"theme,omitempty"` // has defined consts, see above
-	Type          int    `json:"type,omitempty"`  // has defined consts, see above
+	Theme         string `json:"theme,omitempty"`  // has defined consts, see above
+	Type          int    `json:"type,omitempty"`   // has defined consts, see above, used before Zabbix 5.2
+	RoleID        int    `json:"roleid,omitempty"` // used since Zabbix 5.2
 	URL           string `json:"url,omitempty"`
 
 	// used for user.login
@@ -104,6 +116,37 @@ type UserGetParams struct {
 	SelectMedias     SelectQuery `json:"selectMedias,omitempty"`
 	SelectMediatypes SelectQuery `json:"selectMediatypes,omitempty"`
 	SelectUsrgrps    SelectQuery `json:"selectUsrgrps,omitempty"`
+
+	// UserType and RoleIDs are sent as `filter.type` and `filter.roleid` params respectively.
+	// `UserType` (see `UserType*` consts) may be used before Zabbix 5.2 only, `RoleIDs` since Zabbix 5.2 only
+	UserType *int  `json:"-"`
+	RoleIDs  []int `json:"-"`
+}
+
+// MarshalJSON is used to put `UserType` and `RoleIDs` into `filter` param
+func (p UserGetParams) MarshalJSON() ([]byte, error) {
+
+	type userGetParams UserGetParams
+
+	r := userGetParams(p)
+
+	if p.UserType != nil || len(p.RoleIDs) > 0 {
+
+		r.Filter = make(map[string]interface{})
+		for k, v := range p.Filter {
+			r.Filter[k] = v
+		}
+
+		if p.UserType != nil {
+			r.Filter["type"] = *p.UserType
+		}
+
+		if len(p.RoleIDs) > 0 {
+			r.Filter["roleid"] = p.RoleIDs
+		}
+	}
+
+	return json.Marshal(r)
 }
 
 // Structure to store creation result
@@ -121,6 +164,22 @@ func (z *Context) UserGet(params UserGetParams) ([]UserObject, int, error) {
 
 	var result []UserObject
 
+	if params.UserType != nil || len(params.RoleIDs) > 0 {
+
+		roles, err := z.apiVersionAtLeast(userRolesVersion)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if params.UserType != nil && roles == true {
+			return nil, 0, fmt.Errorf("filter by user type is not available since Zabbix API version %s, use roles instead", userRolesVersion)
+		}
+
+		if len(params.RoleIDs) > 0 && roles == false {
+			return nil, 0, fmt.Errorf("filter by roles requires Zabbix API version %s or later", userRolesVersion)
+		}
+	}
+
 	status, err := z.request("user.get", params, &result)
 	if err != nil {
 		return nil, status, err
@@ -178,3 +237,53 @@ func (z *Context) userLogout() (bool, int, error) {
 
 	return result, status, nil
 }
+
+// GetSuperAdmins returns users with super admin permissions. Before Zabbix 5.2 users are filtered
+// by `UserTypeSuperAdmin` type, since Zabbix 5.2 by roles of super admin type
+func (z *Context) GetSuperAdmins() ([]UserObject, error) {
+
+	params := UserGetParams{
+		GetParameters: GetParameters{
+			Output: SelectExtendedOutput,
+		},
+	}
+
+	roles, err := z.apiVersionAtLeast(userRolesVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if roles == true {
+
+		var rObjects []struct {
+			RoleID int `json:"roleid"`
+		}
+
+		if _, err := z.request("role.get", map[string]interface{}{
+			"output": []string{"roleid"},
+			"filter": map[string]interface{}{
+				"type": userRoleTypeSuperAdmin,
+			},
+		}, &rObjects); err != nil {
+			return nil, fmt.Errorf("get roles error: %v", err)
+		}
+
+		if len(rObjects) == 0 {
+			return []UserObject{}, nil
+		}
+
+		for _, r := range rObjects {
+			params.RoleIDs = append(params.RoleIDs, r.RoleID)
+		}
+	} else {
+		t := UserTypeSuperAdmin
+		params.UserType = &t
+	}
+
+	uObjects, _, err := z.UserGet(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return uObjects, nil
+}
@@ -1,6 +1,8 @@
 package zabbix
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 )
@@ -132,3 +134,66 @@ func testUserGet(t *testing.T, z Context, uCreatedIDs []int) []UserObject {
 
 	return uObjects
 }
+
+func TestUserGetSuperAdmins(t *testing.T) {
+
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{
+			version:  "5.0.2",
+			expected: `{"type":3}`,
+		},
+		{
+			version:  "5.2.0",
+			expected: `{"roleid":[3,11]}`,
+		},
+	}
+
+	for _, tt := range tests {
+
+		var filter json.RawMessage
+
+		z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+			switch method {
+			case "apiinfo.version":
+				return tt.version, nil
+			case "role.get":
+				return []map[string]interface{}{{"roleid": "3"}, {"roleid": "11"}}, nil
+			case "user.get":
+
+				var p struct {
+					Filter json.RawMessage `json:"filter"`
+				}
+
+				if err := json.Unmarshal(params, &p); err != nil {
+					return nil, err
+				}
+				filter = p.Filter
+
+				return []map[string]interface{}{{"userid": "1", "alias": "Admin"}}, nil
+			}
+
+			return nil, fmt.Errorf("unexpected method %s", method)
+		})
+
+		uObjects, err := z.GetSuperAdmins()
+		closeMock()
+
+		if err != nil {
+			t.Fatalf("User get super admins error (%s): %v", tt.version, err)
+		}
+
+		if string(filter) != tt.expected {
+			t.Errorf("User get super admins error (%s): unexpected filter %s", tt.version, string(filter))
+		}
+
+		if len(uObjects) != 1 || uObjects[0].UserID != 1 {
+			t.Errorf("User get super admins error (%s): unexpected result %v", tt.version, uObjects)
+		}
+	}
+
+	t.Logf("User get super admins: success")
+}
@@ -1,5 +1,7 @@
 package zabbix
 
+import "fmt"
+
 // For `UsergroupObject` field: `DebugMode`
 const (
 	UsergroupDebugModeDisabled = 0
@@ -144,3 +146,80 @@ func (z *Context) UsergroupDelete(usergroupIDs []int) ([]int, int, error) {
 
 	return result.UsrgrpIDs, status, nil
 }
+
+// SetUserGroupRights replaces host group permissions of the user group with `rights`.
+// Current permissions are requested first and the group is not updated if they already
+// match `rights` (regardless of order)
+func (z *Context) SetUserGroupRights(usrGrpID int, rights []UsergroupPermissionObject) error {
+
+	want := make(map[int]int)
+
+	for _, r := range rights {
+
+		switch r.Permission {
+		case UsergroupPermissionDenied, UsergroupPermissionRO, UsergroupPermissionRW:
+		default:
+			return fmt.Errorf("invalid permission %d for host group %d", r.Permission, r.ID)
+		}
+
+		if _, ok := want[r.ID]; ok == true {
+			return fmt.Errorf("duplicate permission for host group %d", r.ID)
+		}
+
+		want[r.ID] = r.Permission
+	}
+
+	ugObjects, _, err := z.UsergroupGet(UsergroupGetParams{
+		UsrgrpIDs:    []int{usrGrpID},
+		SelectRights: SelectExtendedOutput,
+		GetParameters: GetParameters{
+			Output: SelectFields{"usrgrpid"},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("get user group error: %v", err)
+	}
+
+	if len(ugObjects) == 0 {
+		return fmt.Errorf("user group with id %d not found", usrGrpID)
+	}
+
+	current := make(map[int]int)
+	for _, r := range ugObjects[0].Rights {
+		current[r.ID] = r.Permission
+	}
+
+	if len(current) == len(want) {
+
+		changed := false
+
+		for id, p := range want {
+			if v, ok := current[id]; ok == false || v != p {
+				changed = true
+				break
+			}
+		}
+
+		if changed == false {
+			return nil
+		}
+	}
+
+	if rights == nil {
+		rights = []UsergroupPermissionObject{}
+	}
+
+	// Map is used to send empty `rights` array, it is omitted in `UsergroupObject`
+	var result usergroupUpdateResult
+
+	if _, err := z.request("usergroup.update", []map[string]interface{}{
+		{
+			"usrgrpid": usrGrpID,
+			"rights":   rights,
+		},
+	}, &result); err != nil {
+		return fmt.Errorf("update user group error: %v", err)
+	}
+
+	return nil
+}
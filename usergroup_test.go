@@ -1,6 +1,8 @@
 package zabbix
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 )
@@ -29,6 +31,66 @@ func TestUsergroupCRUD(t *testing.T) {
 	testUsergroupGet(t, z, ugCreatedIDs)
 }
 
+func TestUsergroupSetRights(t *testing.T) {
+
+	var updated []json.RawMessage
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "usergroup.get":
+
+			return []map[string]interface{}{
+				{
+					"usrgrpid": "7",
+					"rights": []map[string]interface{}{
+						{"id": "2", "permission": "3"},
+						{"id": "4", "permission": "2"},
+					},
+				},
+			}, nil
+		case "usergroup.update":
+
+			updated = append(updated, params)
+
+			return map[string]interface{}{"usrgrpids": []string{"7"}}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	// Same rights in other order, no-op
+	if err := z.SetUserGroupRights(7, []UsergroupPermissionObject{
+		{ID: 4, Permission: UsergroupPermissionRO},
+		{ID: 2, Permission: UsergroupPermissionRW},
+	}); err != nil {
+		t.Fatal("Usergroup set rights error:", err)
+	}
+
+	if len(updated) != 0 {
+		t.Errorf("Usergroup set rights error: unexpected update %s", updated)
+	}
+
+	// Changed rights
+	if err := z.SetUserGroupRights(7, []UsergroupPermissionObject{
+		{ID: 2, Permission: UsergroupPermissionDenied},
+	}); err != nil {
+		t.Fatal("Usergroup set rights error:", err)
+	}
+
+	if len(updated) != 1 || string(updated[0]) != `[{"rights":[{"id":2,"permission":0}],"usrgrpid":7}]` {
+		t.Errorf("Usergroup set rights error: unexpected update %s", updated)
+	}
+
+	// Invalid permission
+	if err := z.SetUserGroupRights(7, []UsergroupPermissionObject{{ID: 2, Permission: 1}}); err == nil {
+		t.Error("Usergroup set rights error: expected error for invalid permission")
+	}
+
+	t.Logf("Usergroup set rights: success")
+}
+
 func testUsergroupCreate(t *testing.T, z Context) []int {
 
 	ugCreatedIDs, _, err := z.UsergroupCreate([]UsergroupObject{
@@ -1,11 +1,23 @@
 package zabbix
 
+import (
+	"fmt"
+	"sort"
+)
+
 // For `UsermacroObject` field: `Type`
 const (
 	UsermacroTypeText   = 0
 	UsermacroTypeSecret = 1
 )
 
+// For `ResolveHostMacro` return value: `source`
+const (
+	UsermacroSourceHost     = "host"
+	UsermacroSourceTemplate = "template"
+	UsermacroSourceGlobal   = "global"
+)
+
 // UsermacroObject struct is used to store hostmacro and globalmacro operations results.
 // In API docs Global and Host it is a two different object types that are joined in this package
 // into one object `UsermacroObject` that includes fields form both API objects.
@@ -61,6 +73,11 @@ type globalmacroCreateResult struct {
 	GlobalmacroIDs []int `json:"globalmacroids"`
 }
 
+// Structure to store updation result
+type hostmacroUpdateResult struct {
+	HostmacroIDs []int `json:"hostmacroids"`
+}
+
 // Structure to store deletion result
 type hostmacroDeleteResult struct {
 	HostmacroIDs []int `json:"hostmacroids"`
@@ -135,3 +152,197 @@ func (z *Context) GlobalmacroDelete(globalmacroIDs []int) ([]int, int, error) {
 
 	return result.GlobalmacroIDs, status, nil
 }
+
+// ResolveHostMacro resolves the effective value of the `macro` for the host with the ID `hostID`.
+// The value is looked up on the host itself first, then in the linked templates (level by level,
+// templates within a level are checked in order of their IDs) and finally in the global macros.
+// The returned `source` is one of the `UsermacroSource*` consts.
+func (z *Context) ResolveHostMacro(hostID int, macro string) (value string, source string, err error) {
+
+	// Host macros
+	mObjects, err := z.usermacroFind(UsermacroGetParams{HostIDs: []int{hostID}}, macro)
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(mObjects) > 0 {
+		return mObjects[0].Value, UsermacroSourceHost, nil
+	}
+
+	// Linked templates macros
+	hObjects, _, err := z.HostGet(HostGetParams{
+		HostIDs:               []int{hostID},
+		SelectParentTemplates: SelectFields{"templateid"},
+		GetParameters: GetParameters{
+			Output: SelectFields{"hostid"},
+		},
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(hObjects) == 0 {
+		return "", "", fmt.Errorf("host with id %d not found", hostID)
+	}
+
+	visited := make(map[int]bool)
+	level := templateIDs(hObjects[0].ParentTemplates, visited)
+
+	for len(level) > 0 {
+
+		mObjects, err := z.usermacroFind(UsermacroGetParams{HostIDs: level}, macro)
+		if err != nil {
+			return "", "", err
+		}
+
+		for _, tID := range level {
+			for _, m := range mObjects {
+				if m.HostID == tID {
+					return m.Value, UsermacroSourceTemplate, nil
+				}
+			}
+		}
+
+		tObjects, _, err := z.TemplateGet(TemplateGetParams{
+			TemplateIDs:           level,
+			SelectParentTemplates: SelectFields{"templateid"},
+			GetParameters: GetParameters{
+				Output: SelectFields{"templateid"},
+			},
+		})
+		if err != nil {
+			return "", "", err
+		}
+
+		var parents []TemplateObject
+		for _, t := range tObjects {
+			parents = append(parents, t.ParentTemplates...)
+		}
+
+		level = templateIDs(parents, visited)
+	}
+
+	// Global macros
+	mObjects, err = z.usermacroFind(UsermacroGetParams{Globalmacro: true}, macro)
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(mObjects) > 0 {
+		return mObjects[0].Value, UsermacroSourceGlobal, nil
+	}
+
+	return "", "", fmt.Errorf("macro %s is not defined for host with id %d", macro, hostID)
+}
+
+// SetGroupMacro sets the `macro` to the `value` on each host of the host group: macro is created
+// on hosts where it is not defined and updated on hosts where it has another value.
+// Returns number of changed (created or updated) hosts
+func (z *Context) SetGroupMacro(groupID int, macro, value string) (int, error) {
+
+	var (
+		hCreate []map[string]interface{}
+		mUpdate []map[string]interface{}
+	)
+
+	hObjects, _, err := z.HostGet(HostGetParams{
+		GroupIDs: []int{groupID},
+		GetParameters: GetParameters{
+			Output: SelectFields{"hostid"},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("get hosts error: %v", err)
+	}
+
+	if len(hObjects) == 0 {
+		return 0, nil
+	}
+
+	var hostIDs []int
+	for _, h := range hObjects {
+		hostIDs = append(hostIDs, h.HostID)
+	}
+
+	mObjects, err := z.usermacroFind(UsermacroGetParams{HostIDs: hostIDs}, macro)
+	if err != nil {
+		return 0, fmt.Errorf("get host macros error: %v", err)
+	}
+
+	existing := make(map[int]UsermacroObject)
+	for _, m := range mObjects {
+		existing[m.HostID] = m
+	}
+
+	// Maps are used to be able to set an empty value
+	for _, id := range hostIDs {
+
+		m, b := existing[id]
+		if b == false {
+			hCreate = append(hCreate, map[string]interface{}{
+				"hostid": id,
+				"macro":  macro,
+				"value":  value,
+			})
+			continue
+		}
+
+		if m.Value != value {
+			mUpdate = append(mUpdate, map[string]interface{}{
+				"hostmacroid": m.HostmacroID,
+				"value":       value,
+			})
+		}
+	}
+
+	if len(hCreate) > 0 {
+
+		var result hostmacroCreateResult
+
+		if _, err := z.request("usermacro.create", hCreate, &result); err != nil {
+			return 0, fmt.Errorf("create host macros error: %v", err)
+		}
+	}
+
+	if len(mUpdate) > 0 {
+
+		var result hostmacroUpdateResult
+
+		if _, err := z.request("usermacro.update", mUpdate, &result); err != nil {
+			return len(hCreate), fmt.Errorf("update host macros error: %v", err)
+		}
+	}
+
+	return len(hCreate) + len(mUpdate), nil
+}
+
+// usermacroFind gets macros with the specified name
+func (z *Context) usermacroFind(params UsermacroGetParams, macro string) ([]UsermacroObject, error) {
+
+	params.Filter = map[string]interface{}{
+		"macro": macro,
+	}
+	params.Output = SelectExtendedOutput
+
+	mObjects, _, err := z.UsermacroGet(params)
+
+	return mObjects, err
+}
+
+// templateIDs returns sorted IDs of not yet visited templates and marks them as visited
+func templateIDs(templates []TemplateObject, visited map[int]bool) []int {
+
+	var ids []int
+
+	for _, t := range templates {
+		if visited[t.TemplateID] == true {
+			continue
+		}
+		visited[t.TemplateID] = true
+		ids = append(ids, t.TemplateID)
+	}
+
+	sort.Ints(ids)
+
+	return ids
+}
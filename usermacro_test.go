@@ -1,6 +1,8 @@
 package zabbix
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 )
@@ -106,3 +108,155 @@ func testHostmacroGet(t *testing.T, z Context, hmCreatedIDs []int) []UsermacroOb
 
 	return hmObjects
 }
+
+func TestHostmacroSetGroupMacro(t *testing.T) {
+
+	var (
+		created []map[string]interface{}
+		updated []map[string]interface{}
+		value   = "5"
+	)
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "host.get":
+
+			return []map[string]interface{}{{"hostid": "10084"}, {"hostid": "10085"}, {"hostid": "10086"}}, nil
+		case "usermacro.get":
+
+			// Macro is absent on the first host
+			return []map[string]interface{}{
+				{"hostmacroid": "2", "hostid": "10085", "macro": "{$THRESHOLD}", "value": "3"},
+				{"hostmacroid": "3", "hostid": "10086", "macro": "{$THRESHOLD}", "value": value},
+			}, nil
+		case "usermacro.create":
+
+			if err := json.Unmarshal(params, &created); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"hostmacroids": []string{"4"}}, nil
+		case "usermacro.update":
+
+			if err := json.Unmarshal(params, &updated); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"hostmacroids": []string{"2"}}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	n, err := z.SetGroupMacro(5, "{$THRESHOLD}", value)
+	if err != nil {
+		t.Fatal("Hostmacro set group macro error:", err)
+	}
+
+	if n != 2 {
+		t.Errorf("Hostmacro set group macro error: expected 2 changed hosts, got %d", n)
+	}
+
+	expectedCreated := []map[string]interface{}{{"hostid": float64(10084), "macro": "{$THRESHOLD}", "value": "5"}}
+	if reflect.DeepEqual(created, expectedCreated) == false {
+		t.Errorf("Hostmacro set group macro error: unexpected created macros %v", created)
+	}
+
+	expectedUpdated := []map[string]interface{}{{"hostmacroid": float64(2), "value": "5"}}
+	if reflect.DeepEqual(updated, expectedUpdated) == false {
+		t.Errorf("Hostmacro set group macro error: unexpected updated macros %v", updated)
+	}
+
+	t.Logf("Hostmacro set group macro: success")
+}
+
+func TestHostmacroResolve(t *testing.T) {
+
+	const (
+		hostID      = 1
+		templateID  = 10
+		parentTplID = 20
+	)
+
+	tests := []struct {
+		name   string
+		macros map[int]string // host or template ID (0 for global) -> macro value
+		value  string
+		source string
+	}{
+		{
+			name:   "host",
+			macros: map[int]string{hostID: "hostValue", templateID: "templateValue", 0: "globalValue"},
+			value:  "hostValue",
+			source: UsermacroSourceHost,
+		},
+		{
+			name:   "template",
+			macros: map[int]string{parentTplID: "parentTemplateValue", 0: "globalValue"},
+			value:  "parentTemplateValue",
+			source: UsermacroSourceTemplate,
+		},
+		{
+			name:   "global",
+			macros: map[int]string{0: "globalValue"},
+			value:  "globalValue",
+			source: UsermacroSourceGlobal,
+		},
+	}
+
+	for _, tt := range tests {
+
+		z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+			var p UsermacroGetParams
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			switch method {
+			case "host.get":
+				return []map[string]interface{}{
+					{"hostid": hostID, "parentTemplates": []map[string]interface{}{{"templateid": templateID}}},
+				}, nil
+			case "template.get":
+				if reflect.DeepEqual(p.TemplateIDs, []int{templateID}) {
+					return []map[string]interface{}{
+						{"templateid": templateID, "parentTemplates": []map[string]interface{}{{"templateid": parentTplID}}},
+					}, nil
+				}
+				return []map[string]interface{}{}, nil
+			case "usermacro.get":
+				r := []map[string]interface{}{}
+				if p.Globalmacro == true {
+					if v, b := tt.macros[0]; b == true {
+						r = append(r, map[string]interface{}{"macro": testHostmacroMacro, "value": v})
+					}
+					return r, nil
+				}
+				for _, id := range p.HostIDs {
+					if v, b := tt.macros[id]; b == true {
+						r = append(r, map[string]interface{}{"hostid": id, "macro": testHostmacroMacro, "value": v})
+					}
+				}
+				return r, nil
+			}
+
+			return nil, fmt.Errorf("unexpected method %s", method)
+		})
+
+		value, source, err := z.ResolveHostMacro(hostID, testHostmacroMacro)
+		closeMock()
+
+		if err != nil {
+			t.Fatalf("Hostmacro resolve error (%s): %v", tt.name, err)
+		}
+
+		if value != tt.value || source != tt.source {
+			t.Errorf("Hostmacro resolve error (%s): got value `%s` from `%s`", tt.name, value, source)
+		}
+	}
+
+	t.Logf("Hostmacro resolve: success")
+}
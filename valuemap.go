@@ -0,0 +1,116 @@
+package zabbix
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ValuemapObject struct is used to store value map operations results
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/valuemap/object#value_map
+type ValuemapObject struct {
+	ValuemapID int    `json:"valuemapid,omitempty"`
+	Name       string `json:"name,omitempty"`
+
+	Mappings []ValuemapMappingObject `json:"mappings,omitempty"`
+}
+
+// ValuemapMappingObject struct is used to store value mappings
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/valuemap/object#value_mappings
+type ValuemapMappingObject struct {
+	Value    string `json:"value"`
+	NewValue string `json:"newvalue"`
+}
+
+// ValuemapGetParams struct is used for value map get requests
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api/reference/valuemap/get#parameters
+type ValuemapGetParams struct {
+	GetParameters
+
+	ValuemapIDs []int `json:"valuemapids,omitempty"`
+
+	SelectMappings SelectQuery `json:"selectMappings,omitempty"`
+}
+
+// valuemapCache is used to store value maps by IDs
+type valuemapCache struct {
+	mu       sync.Mutex
+	mappings map[int]map[string]string
+}
+
+// ValuemapGet gets value maps
+func (z *Context) ValuemapGet(params ValuemapGetParams) ([]ValuemapObject, int, error) {
+
+	var result []ValuemapObject
+
+	status, err := z.request("valuemap.get", params, &result)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return result, status, nil
+}
+
+// MapItemValue returns the `value` of the `item` mapped through the item's value map.
+// The value is returned as is if item has no value map or value map has no mapping for it.
+// Value maps are requested once and cached by IDs
+func (z *Context) MapItemValue(item ItemObject, value string) (string, error) {
+
+	if item.ValueMapID == 0 {
+		return value, nil
+	}
+
+	c := z.valuemapCache()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m, b := c.mappings[item.ValueMapID]
+	if b == false {
+
+		vmObjects, _, err := z.ValuemapGet(ValuemapGetParams{
+			ValuemapIDs:    []int{item.ValueMapID},
+			SelectMappings: SelectExtendedOutput,
+			GetParameters: GetParameters{
+				Output: SelectFields{"valuemapid"},
+			},
+		})
+		if err != nil {
+			return "", err
+		}
+
+		if len(vmObjects) == 0 {
+			return "", fmt.Errorf("value map with id %d not found", item.ValueMapID)
+		}
+
+		m = make(map[string]string)
+		for _, v := range vmObjects[0].Mappings {
+			m[v.Value] = v.NewValue
+		}
+
+		c.mappings[item.ValueMapID] = m
+	}
+
+	if v, b := m[value]; b == true {
+		return v, nil
+	}
+
+	return value, nil
+}
+
+// valuemapCache returns value maps cache of the context, cache is created on first use
+func (z *Context) valuemapCache() *valuemapCache {
+
+	contextCachesMu.Lock()
+	defer contextCachesMu.Unlock()
+
+	if z.valuemaps == nil {
+		z.valuemaps = &valuemapCache{
+			mappings: make(map[int]map[string]string),
+		}
+	}
+
+	return z.valuemaps
+}
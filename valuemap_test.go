@@ -0,0 +1,122 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestValuemapMapItemValue(t *testing.T) {
+
+	var calls int
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "valuemap.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		calls++
+
+		return []map[string]interface{}{
+			{
+				"valuemapid": "15",
+				"mappings": []map[string]interface{}{
+					{"value": "0", "newvalue": "Down"},
+					{"value": "1", "newvalue": "Up"},
+				},
+			},
+		}, nil
+	})
+	defer closeMock()
+
+	item := ItemObject{
+		ItemID:     1001,
+		ValueMapID: 15,
+	}
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "1", expected: "Up"},
+		{value: "0", expected: "Down"},
+		{value: "2", expected: "2"},
+	}
+
+	for _, tt := range tests {
+
+		v, err := z.MapItemValue(item, tt.value)
+		if err != nil {
+			t.Fatal("Valuemap map item value error:", err)
+		}
+
+		if v != tt.expected {
+			t.Errorf("Valuemap map item value error: expected %s for %s, got %s", tt.expected, tt.value, v)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("Valuemap map item value error: expected 1 request, got %d", calls)
+	}
+
+	// Item without value map
+	if v, err := z.MapItemValue(ItemObject{ItemID: 1002}, "1"); err != nil || v != "1" {
+		t.Errorf("Valuemap map item value error: unexpected value %s (%v)", v, err)
+	}
+
+	t.Logf("Valuemap map item value: success")
+}
+
+func TestValuemapMapItemValueConcurrent(t *testing.T) {
+
+	var (
+		mu    sync.Mutex
+		calls int
+		wg    sync.WaitGroup
+	)
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "valuemap.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		mu.Lock()
+		calls++
+		mu.Unlock()
+
+		return []map[string]interface{}{
+			{
+				"valuemapid": "15",
+				"mappings":   []map[string]interface{}{{"value": "1", "newvalue": "Up"}},
+			},
+		}, nil
+	})
+	defer closeMock()
+
+	item := ItemObject{
+		ItemID:     1001,
+		ValueMapID: 15,
+	}
+
+	// Concurrent first calls must share the same cache
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := z.MapItemValue(item, "1"); err != nil || v != "Up" {
+				t.Errorf("Valuemap map item value concurrent error: unexpected value %s (%v)", v, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("Valuemap map item value concurrent error: expected 1 request, got %d", calls)
+	}
+
+	t.Logf("Valuemap map item value concurrent: success")
+}
@@ -1,12 +1,22 @@
 package zabbix
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 )
@@ -23,10 +33,165 @@ const (
 	GetParametersSortOrderDESC = "DESC"
 )
 
+// Errors returned by `Ping()`
+var (
+	ErrPingUnreachable = errors.New("zabbix api is unreachable")
+	ErrPingAuthFailed  = errors.New("zabbix api authentication failed")
+)
+
+// ErrInvalidResponse is returned when Zabbix API (or some intermediary, e.g. reverse proxy)
+// responds with a body that is not a valid JSON-RPC 2.0 response
+var ErrInvalidResponse = errors.New("invalid json-rpc response")
+
+// TransportError is returned when request to Zabbix API fails at the HTTP level: either connection
+// fails (`Err` is set) or response has non-200 status (`StatusCode` and `Body` are set).
+// Errors returned by Zabbix API itself are of `ZabbixError` type
+type TransportError struct {
+	StatusCode int
+	Body       string
+	Err        error
+}
+
+func (e *TransportError) Error() string {
+
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("http status %d: %s", e.StatusCode, e.Body)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// ZabbixError is returned when Zabbix API responds with an error
+//
+// see: https://www.zabbix.com/documentation/5.0/manual/api#error_handling
+type ZabbixError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+func (e *ZabbixError) Error() string {
+	return e.Data + " " + e.Message
+}
+
+// Selector name in Zabbix API errors about unexpected params,
+// e.g. `Invalid parameter "/": unexpected parameter "selectValueMap".`
+var selectorRejectedRegexp = regexp.MustCompile(`parameter "(select[A-Za-z]+)"`)
+
+// Max length of response body snippet included into `ErrInvalidResponse` errors
+const responseSnippetLen = 128
+
+// Settings for reconnects, see `WithReconnect()`
+const (
+	reconnectAttempts       = 5
+	reconnectBackoffInitial = 100 * time.Millisecond
+)
+
+// BatchResult is used to store results of operations sending a separate request per object
+// (e.g. `CreateItemsIndividually()`), so some objects may be processed while others fail
+type BatchResult struct {
+	Succeeded []int          // IDs of processed objects
+	Failed    []BatchFailure // failures in the order of objects
+}
+
+// BatchFailure is used to store failure of a single object within `BatchResult`.
+// `Index` is the object index in the operation params
+type BatchFailure struct {
+	Index int
+	Err   error
+}
+
+// Parameters of `massupdate` methods for entities supporting it, see `MassUpdate()`
+var massUpdateEntities = map[string]struct {
+	objects string
+	id      string
+}{
+	"host":      {objects: "hosts", id: "hostid"},
+	"hostgroup": {objects: "groups", id: "groupid"},
+	"template":  {objects: "templates", id: "templateid"},
+}
+
+// Default value for `User-Agent` header sent with requests to Zabbix API
+const userAgentDefault = "nxs-go-zabbix/v5"
+
+// API methods which are safe to repeat on reconnects besides `*.get` ones (see `WithReconnect()`),
+// since they do not change objects on Zabbix side
+var reconnectRepeatableMethods = map[string]bool{
+	"apiinfo.version":          true,
+	"user.login":               true,
+	"user.checkAuthentication": true,
+	"configuration.export":     true,
+}
+
+// API methods that must be called without `auth` parameter
+var requestNoAuthMethods = map[string]bool{
+	"apiinfo.version":          true,
+	"user.login":               true,
+	"user.checkAuthentication": true,
+}
+
+// Protects lazy creation of the context caches (e.g. proxy names cache) and session mutex,
+// since context may be used by several goroutines concurrently since the first call
+var contextCachesMu sync.Mutex
+
 // Context struct is used for store settings to communicate with Zabbix API
 type Context struct {
 	sessionKey string
 	host       string
+	apiVersion string
+
+	// Protects `sessionKey` since it may be changed by session keepalive in the background,
+	// see `StartSessionKeepalive()`. Created on first use, see `sessionLock()`
+	sessionMu *sync.RWMutex
+
+	// Credentials are kept to re-establish the session on reconnects
+	user     string
+	password string
+
+	// Max delay between reconnect attempts, reconnects are disabled if zero
+	reconnectMaxBackoff time.Duration
+
+	// Headers are added to each request to Zabbix API (e.g. to pass auth proxies or trace headers).
+	// Values set here override the default ones (e.g. `User-Agent`), except the `Content-Type` header
+	Headers http.Header
+
+	// FrontendURL is the base URL of Zabbix frontend (e.g. `https://zabbix.example.com/`).
+	// Zabbix API does not provide the frontend location, so it must be set to use methods
+	// building frontend URLs (e.g. `GraphImageURL()`)
+	FrontendURL string
+
+	// HTTPClient is used to send requests to Zabbix API if set. Settings of the package's own client
+	// (e.g. `SetInsecureSkipVerify()`) are not applied to this client
+	HTTPClient *http.Client
+
+	// OnHistoryTruncated is called by `HistoryGet()` if number of returned records reaches the limit,
+	// i.e. history is likely truncated. `count` is the number of returned records
+	OnHistoryTruncated func(params HistoryGetParams, count int)
+
+	// LenientSelectors allows to degrade gracefully on Zabbix versions not supporting some `select*`
+	// params: if Zabbix API rejects the selector, request is retried once without it. By default
+	// (strict mode) such requests fail
+	LenientSelectors bool
+
+	// OnSelectorRejected is called when the selector is dropped in lenient mode (see `LenientSelectors`).
+	// If not set, a warning is written with the standard logger
+	OnSelectorRejected func(method, selector string)
+
+	// Proxy names cache, see `ProxyName()`
+	proxies *proxyCache
+
+	// Value maps cache, see `MapItemValue()`
+	valuemaps *valuemapCache
+
+	// Server capabilities cache, see `Capabilities()`
+	capabilities *ServerCapabilities
+
+	// Package's own client, `http.DefaultClient` is used if not set
+	client *http.Client
 }
 
 // GetParameters struct is used as embedded struct for some other structs within package
@@ -48,7 +213,9 @@ type GetParameters struct {
 	StartSearch            bool                   `json:"startSearch,omitempty"`
 }
 
-// SelectQuery is used as field type in some structs
+// SelectQuery is used as field type in some structs. Value may be `SelectExtendedOutput`,
+// `SelectCount` (for selectors supporting it) or `SelectFields` to restrict fields of
+// returned objects, e.g. `SelectHosts: SelectFields{"hostid", "name"}`
 type SelectQuery interface{}
 
 // SelectFields is used as field type in some structs
@@ -65,19 +232,13 @@ type requestData struct {
 type responseData struct {
 	JSONRPC string      `json:"jsonrpc"`
 	Result  interface{} `json:"result"`
-	Error   struct {
-		Code    int    `json:"code"`
-		Message string `json:"message"`
-		Data    string `json:"data"`
-	} `json:"error"`
-	ID int `json:"id"`
+	Error   ZabbixError `json:"error"`
+	ID      int         `json:"id"`
 }
 
 // Login gets the Zabbix session
 func (z *Context) Login(host, user, password string) error {
 
-	var err error
-
 	z.host = host
 
 	r := UserLoginParams{
@@ -85,10 +246,15 @@ func (z *Context) Login(host, user, password string) error {
 		Password: password,
 	}
 
-	if z.sessionKey, _, err = z.userLogin(r); err != nil {
+	sessionKey, _, err := z.userLogin(r)
+	if err != nil {
 		return err
 	}
 
+	z.sessionSet(sessionKey)
+	z.user = user
+	z.password = password
+
 	return nil
 }
 
@@ -97,7 +263,9 @@ func (z *Context) Logout() error {
 
 	_, _, err := z.userLogout()
 
-	z.sessionKey = ""
+	z.sessionSet("")
+	z.user = ""
+	z.password = ""
 
 	if err != nil {
 		return err
@@ -106,7 +274,43 @@ func (z *Context) Logout() error {
 	return nil
 }
 
+// Ping checks Zabbix API is reachable and, if context has a session, the session is valid.
+// Returned error wraps `ErrPingUnreachable` or `ErrPingAuthFailed` respectively
+func (z *Context) Ping() error {
+
+	if _, _, err := z.APIInfoVersion(); err != nil {
+		return fmt.Errorf("%w: %v", ErrPingUnreachable, err)
+	}
+
+	if z.session() == "" {
+		return nil
+	}
+
+	if _, _, err := z.UserGet(UserGetParams{
+		GetParameters: GetParameters{
+			Output: SelectFields{"userid"},
+			Limit:  1,
+		},
+	}); err != nil {
+		return fmt.Errorf("%w: %v", ErrPingAuthFailed, err)
+	}
+
+	return nil
+}
+
 func (z *Context) request(method string, params interface{}, result interface{}) (int, error) {
+	return z.requestCtx(context.Background(), method, params, result)
+}
+
+// requestCtx sends request to Zabbix API within the `ctx`. If context is canceled or its deadline
+// is exceeded, the request is aborted and context error wrapped with the method name is returned
+func (z *Context) requestCtx(ctx context.Context, method string, params interface{}, result interface{}) (int, error) {
+	return z.requestSend(ctx, method, params, result, z.LenientSelectors)
+}
+
+// requestSend sends request to Zabbix API. If `retrySelectors` is set and Zabbix API rejects
+// a selector, request is repeated once without it
+func (z *Context) requestSend(ctx context.Context, method string, params interface{}, result interface{}, retrySelectors bool) (int, error) {
 
 	resp := responseData{
 		Result: result,
@@ -116,60 +320,422 @@ func (z *Context) request(method string, params interface{}, result interface{})
 		JSONRPC: "2.0",
 		Method:  method,
 		Params:  params,
-		Auth:    z.sessionKey,
 		ID:      1,
 	}
 
-	status, err := z.httpPost(req, &resp)
+	if requestNoAuthMethods[method] == false {
+		req.Auth = z.session()
+	}
+
+	status, err := z.httpPost(ctx, req, &resp)
+	if err != nil && ctx.Err() == nil && z.reconnectMaxBackoff > 0 && isConnectionError(err) == true && reconnectRepeatable(method) == true {
+		status, err = z.reconnect(ctx, req, &resp)
+	}
 	if err != nil {
+		if ctx.Err() != nil {
+			return status, fmt.Errorf("%s request error: %w", method, ctx.Err())
+		}
 		return status, err
 	}
 
 	if resp.Error.Code != 0 {
-		return status, errors.New(resp.Error.Data + " " + resp.Error.Message)
+
+		e := resp.Error
+
+		if retrySelectors == true {
+			if p, selector, b := selectorDrop(params, &e); b == true {
+
+				if z.OnSelectorRejected != nil {
+					z.OnSelectorRejected(method, selector)
+				} else {
+					log.Printf("zabbix: `%s` param is rejected by `%s` method, retrying without it", selector, method)
+				}
+
+				return z.requestSend(ctx, method, p, result, false)
+			}
+		}
+
+		return status, &e
 	}
 
 	return status, nil
 }
 
-func (z *Context) httpPost(in interface{}, out interface{}) (int, error) {
+// selectorDrop checks the error is caused by unsupported selector and returns `params` without it.
+// False is returned if the selector is not found in the error or in `params`
+func selectorDrop(params interface{}, e *ZabbixError) (map[string]interface{}, string, bool) {
+
+	m := selectorRejectedRegexp.FindStringSubmatch(e.Data)
+	if m == nil {
+		return nil, "", false
+	}
+
+	s, err := json.Marshal(params)
+	if err != nil {
+		return nil, "", false
+	}
+
+	// Numbers are kept as `json.Number` to not lose precision of big IDs
+	p := make(map[string]interface{})
+
+	d := json.NewDecoder(bytes.NewReader(s))
+	d.UseNumber()
+
+	if err := d.Decode(&p); err != nil {
+		return nil, "", false
+	}
+
+	if _, b := p[m[1]]; b == false {
+		return nil, "", false
+	}
+
+	delete(p, m[1])
+
+	return p, m[1], true
+}
+
+// StartSessionKeepalive starts a background goroutine periodically checking the session with
+// `user.checkAuthentication` method, that also prolongs the session on Zabbix side. If the session
+// is expired, context re-logins with the credentials used on `Login()`. Keepalive stops when `ctx`
+// is done. Check errors not related to the session (e.g. connection errors) are ignored
+// until the next check
+func (z *Context) StartSessionKeepalive(ctx context.Context, interval time.Duration) {
+
+	go func() {
+
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				z.sessionKeepalive(ctx)
+			}
+		}
+	}()
+}
+
+// sessionKeepalive checks the session and re-logins if it is expired
+func (z *Context) sessionKeepalive(ctx context.Context) {
+
+	var (
+		result     interface{}
+		sessionKey string
+		ze         *ZabbixError
+	)
+
+	_, err := z.requestCtx(ctx, "user.checkAuthentication", map[string]string{
+		"sessionid": z.session(),
+	}, &result)
+	if err == nil || errors.As(err, &ze) == false || z.user == "" {
+		return
+	}
+
+	if _, err := z.requestCtx(ctx, "user.login", UserLoginParams{
+		User:     z.user,
+		Password: z.password,
+	}, &sessionKey); err != nil {
+		return
+	}
+
+	z.sessionSet(sessionKey)
+}
+
+// session returns current session key
+func (z *Context) session() string {
+
+	mu := z.sessionLock()
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return z.sessionKey
+}
+
+// sessionSet sets session key
+func (z *Context) sessionSet(sessionKey string) {
+
+	mu := z.sessionLock()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	z.sessionKey = sessionKey
+}
+
+// sessionLock returns mutex protecting the session key. Mutex is created on first use, so
+// contexts created without `Login()` (e.g. with session set by the caller) are protected as well
+func (z *Context) sessionLock() *sync.RWMutex {
+
+	contextCachesMu.Lock()
+	defer contextCachesMu.Unlock()
+
+	if z.sessionMu == nil {
+		z.sessionMu = &sync.RWMutex{}
+	}
+
+	return z.sessionMu
+}
+
+// MassUpdate calls `<entity>.massupdate` method to apply the same `changes` to all objects
+// with specified `ids`, e.g.:
+//
+//	z.MassUpdate("host", hostIDs, map[string]interface{}{"status": HostStatusUnmonitored})
+//
+// Only entities supporting `massupdate` method are allowed: `host`, `hostgroup` and `template`
+// (items and triggers do not have such method in Zabbix API)
+func (z *Context) MassUpdate(entity string, ids []int, changes map[string]interface{}) ([]int, error) {
+
+	var result map[string][]int
+
+	e, b := massUpdateEntities[entity]
+	if b == false {
+		return nil, fmt.Errorf("entity `%s` does not support massupdate", entity)
+	}
+
+	if len(ids) == 0 {
+		return nil, errors.New("no ids specified")
+	}
+
+	if len(changes) == 0 {
+		return nil, errors.New("no changes specified")
+	}
+
+	params := make(map[string]interface{})
+	for k, v := range changes {
+		params[k] = v
+	}
+
+	objects := []map[string]int{}
+	for _, id := range ids {
+		objects = append(objects, map[string]int{e.id: id})
+	}
+	params[e.objects] = objects
+
+	if _, err := z.request(entity+".massupdate", params, &result); err != nil {
+		return nil, err
+	}
+
+	return result[e.id+"s"], nil
+}
+
+// SetInsecureSkipVerify disables (or enables back) verification of Zabbix server TLS certificate.
+//
+// WARNING: with verification disabled any certificate presented by the server is accepted, so the
+// connection (including credentials) is vulnerable to man-in-the-middle attacks. Use it for lab
+// or test servers with self-signed certificates only.
+//
+// Setting does not affect the client set in `HTTPClient` field
+func (z *Context) SetInsecureSkipVerify(skip bool) {
+
+	if z.client == nil {
+		z.client = &http.Client{
+			Transport: http.DefaultTransport.(*http.Transport).Clone(),
+		}
+	}
+
+	t := z.client.Transport.(*http.Transport)
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{}
+	}
+
+	t.TLSClientConfig.InsecureSkipVerify = skip
+}
+
+// httpClient returns the client to send requests to Zabbix API
+func (z *Context) httpClient() *http.Client {
+
+	if z.HTTPClient != nil {
+		return z.HTTPClient
+	}
+
+	if z.client != nil {
+		return z.client
+	}
+
+	return http.DefaultClient
+}
+
+// WithReconnect returns a copy of the context which transparently re-establishes the session (re-login)
+// and repeats the request on connection-level failures (e.g. dropped connections or restarted server).
+// Only read-only requests (`*.get` and some other methods, see `reconnectRepeatableMethods`) are repeated:
+// failed request may have reached the server, so repeating e.g. `item.create` or `event.acknowledge`
+// could apply it twice. Connection errors of other requests are returned as is.
+// Delays between attempts are doubled on each attempt but never exceed `maxBackoff`.
+// Only the returned context is affected, it has its own session after the first reconnect
+func (z *Context) WithReconnect(maxBackoff time.Duration) *Context {
+
+	c := *z
+	c.reconnectMaxBackoff = maxBackoff
+
+	return &c
+}
+
+// reconnect re-logins and repeats the request until it succeeds, fails not at the connection level
+// or all attempts are exhausted
+func (z *Context) reconnect(ctx context.Context, req requestData, resp *responseData) (int, error) {
+
+	var (
+		status int
+		err    error
+	)
+
+	backoff := reconnectBackoffInitial
+
+	for a := 0; a < reconnectAttempts; a++ {
+
+		if backoff > z.reconnectMaxBackoff {
+			backoff = z.reconnectMaxBackoff
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return status, ctx.Err()
+		}
+		backoff *= 2
+
+		if req.Method != "user.login" && z.user != "" {
+
+			// Context copy is used to avoid nested reconnects
+			c := *z
+			c.reconnectMaxBackoff = 0
+
+			var sessionKey string
+
+			status, err = c.requestCtx(ctx, "user.login", UserLoginParams{
+				User:     z.user,
+				Password: z.password,
+			}, &sessionKey)
+			if err != nil {
+				if ctx.Err() == nil && isConnectionError(err) == true {
+					continue
+				}
+				return status, err
+			}
+
+			z.sessionSet(sessionKey)
+			if requestNoAuthMethods[req.Method] == false {
+				req.Auth = sessionKey
+			}
+		}
+
+		status, err = z.httpPost(ctx, req, resp)
+		if err == nil || ctx.Err() != nil || isConnectionError(err) == false {
+			return status, err
+		}
+	}
+
+	return status, err
+}
+
+// reconnectRepeatable checks the request of the method may be repeated on reconnect
+func reconnectRepeatable(method string) bool {
+	return strings.HasSuffix(method, ".get") == true || reconnectRepeatableMethods[method] == true
+}
+
+// isConnectionError checks the error is occurred while communicating with Zabbix server
+func isConnectionError(err error) bool {
+
+	var e *url.Error
+
+	if errors.As(err, &e) == false {
+		return false
+	}
+
+	// Errors of URL parsing are not related to connection
+	return e.Op != "parse"
+}
+
+// requestMarshal encodes request to JSON with object keys sorted at every level, so bodies of
+// identical requests are byte-identical. `encoding/json` sorts keys of maps only, so objects
+// produced by structs and custom marshalers are normalized by decoding and encoding once again
+func requestMarshal(in interface{}) ([]byte, error) {
 
 	s, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+
+	d := json.NewDecoder(bytes.NewReader(s))
+	d.UseNumber()
+
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(v)
+}
+
+func (z *Context) httpPost(ctx context.Context, in interface{}, out interface{}) (int, error) {
+
+	s, err := requestMarshal(in)
 	if err != nil {
 		return 0, err
 	}
-	req, err := http.NewRequest("POST", z.host, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "POST", z.host, strings.NewReader(string(s)))
 	if err != nil {
 		return 0, err
 	}
 
 	// Set headers
-	req.Header.Add("Content-Type", "application/json-rpc")
+	req.Header.Set("User-Agent", userAgentDefault)
+	for k, v := range z.Headers {
+		req.Header[http.CanonicalHeaderKey(k)] = v
+	}
+	req.Header.Set("Content-Type", "application/json-rpc")
 
 	// Make request
-	res, err := http.DefaultClient.Do(req)
+	res, err := z.httpClient().Do(req)
 	if err != nil {
-		return 0, err
+		return 0, &TransportError{
+			Err: err,
+		}
 	}
 
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		if bodyBytes, err := ioutil.ReadAll(res.Body); err == nil {
-			return res.StatusCode, errors.New(string(bodyBytes))
+		bodyBytes, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return res.StatusCode, &TransportError{
+				StatusCode: res.StatusCode,
+				Err:        err,
+			}
+		}
+		return res.StatusCode, &TransportError{
+			StatusCode: res.StatusCode,
+			Body:       string(bodyBytes),
 		}
 	} else {
 		if out != nil {
 
 			rawConf := make(map[string]interface{})
 
-			dJ := json.NewDecoder(res.Body)
+			bodyBytes, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				return res.StatusCode, err
+			}
+
+			// Numbers are decoded as `json.Number` to keep precision of big IDs
+			// (IDs may be sent as numbers instead of strings by some proxies and tools)
+			dJ := json.NewDecoder(bytes.NewReader(bodyBytes))
+			dJ.UseNumber()
+
 			if err := dJ.Decode(&rawConf); err != nil {
-				return res.StatusCode, fmt.Errorf("json decode error: %v", err)
+				return res.StatusCode, fmt.Errorf("%w: %v: %s", ErrInvalidResponse, err, responseSnippet(bodyBytes))
+			}
+
+			if err := responseValidate(rawConf); err != nil {
+				return res.StatusCode, fmt.Errorf("%w: %v: %s", ErrInvalidResponse, err, responseSnippet(bodyBytes))
 			}
 
 			dM, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 				WeaklyTypedInput: true,
+				DecodeHook:       responseDecodeHook,
 				Result:           out,
 				TagName:          "json",
 			})
@@ -185,3 +751,120 @@ func (z *Context) httpPost(in interface{}, out interface{}) (int, error) {
 
 	return res.StatusCode, nil
 }
+
+// responseDecodeHook is used to decode values Zabbix API encodes inconsistently:
+//   - empty objects may be returned as empty arrays (e.g. `interface` of active proxies)
+//   - numbers (decoded as `json.Number`) with fractional part are truncated for integer fields
+//     and treated as booleans for bool fields
+//   - empty strings are decoded as zero values for numeric fields (e.g. `lastclock` of items
+//     never collected a value)
+func responseDecodeHook(from, to reflect.Type, data interface{}) (interface{}, error) {
+
+	if s, b := data.(string); b == true && s == "" {
+		switch to.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return reflect.Zero(to).Interface(), nil
+		}
+		return data, nil
+	}
+
+	if n, b := data.(json.Number); b == true {
+		switch to.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if _, err := n.Int64(); err != nil {
+				if f, err := n.Float64(); err == nil {
+					return int64(f), nil
+				}
+			}
+		case reflect.Bool:
+			if f, err := n.Float64(); err == nil {
+				return f != 0, nil
+			}
+		}
+		return data, nil
+	}
+
+	if from.Kind() != reflect.Slice || reflect.ValueOf(data).Len() != 0 {
+		return data, nil
+	}
+
+	switch to.Kind() {
+	case reflect.Ptr:
+		return nil, nil
+	case reflect.Struct, reflect.Map:
+		return map[string]interface{}{}, nil
+	}
+
+	return data, nil
+}
+
+// responseValidate checks decoded response has `jsonrpc: "2.0"` and either `result` or `error` fields
+func responseValidate(raw map[string]interface{}) error {
+
+	if v, _ := raw["jsonrpc"].(string); v != "2.0" {
+		return errors.New("missing or wrong `jsonrpc` field")
+	}
+
+	_, r := raw["result"]
+	_, e := raw["error"]
+	if r == false && e == false {
+		return errors.New("neither `result` nor `error` field is present")
+	}
+
+	return nil
+}
+
+// responseSnippet returns the beginning of response body to be included into error messages
+func responseSnippet(body []byte) string {
+
+	s := strings.TrimSpace(string(body))
+	if len(s) > responseSnippetLen {
+		return s[:responseSnippetLen] + "..."
+	}
+
+	return s
+}
+
+// durationParse parses Zabbix time value with optional suffix (e.g. `30`, `5m`, `7d` or `2w`).
+// False is returned for values that can not be parsed (e.g. user macros)
+func durationParse(s string) (time.Duration, bool) {
+
+	units := map[byte]time.Duration{
+		's': time.Second,
+		'm': time.Minute,
+		'h': time.Hour,
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+	}
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+
+	unit := time.Second
+	if u, b := units[s[len(s)-1]]; b == true {
+		unit = u
+		s = s[:len(s)-1]
+	}
+
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return time.Duration(n) * unit, true
+}
+
+func containsInt(s []int, e int) bool {
+
+	for _, i := range s {
+		if i == e {
+			return true
+		}
+	}
+
+	return false
+}
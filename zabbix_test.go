@@ -1,8 +1,19 @@
 package zabbix
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func loginTest(z *Context, t *testing.T) {
@@ -37,3 +48,759 @@ func logoutTest(z *Context, t *testing.T) {
 		t.Logf("Logout: success")
 	}
 }
+
+// testMockHandler is used to emulate Zabbix API methods within mock server.
+// Returned error is sent to client as Zabbix API error
+type testMockHandler func(method string, params json.RawMessage) (interface{}, error)
+
+// testMockContext starts mock Zabbix API server and returns context to communicate with it
+func testMockContext(t *testing.T, handler testMockHandler) (*Context, func()) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		var req struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+			ID     int             `json:"id"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error("Mock server error:", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resp := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+		}
+
+		result, err := handler(req.Method, req.Params)
+		if err != nil {
+			resp["error"] = map[string]interface{}{
+				"code":    -32602,
+				"message": "Invalid params.",
+				"data":    err.Error(),
+			}
+		} else {
+			resp["result"] = result
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+
+	z := &Context{
+		host:       srv.URL,
+		sessionKey: "mockSessionKey",
+	}
+
+	return z, srv.Close
+}
+
+func TestContextHeaders(t *testing.T) {
+
+	var header http.Header
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(`{"jsonrpc":"2.0","result":[],"id":1}`))
+	}))
+	defer srv.Close()
+
+	z := Context{
+		host: srv.URL,
+		Headers: http.Header{
+			"X-Trace-Id":   []string{"testTraceID"},
+			"Content-Type": []string{"text/plain"},
+		},
+	}
+
+	if _, _, err := z.HostgroupGet(HostgroupGetParams{}); err != nil {
+		t.Fatal("Context headers error:", err)
+	}
+
+	if ua := header.Get("User-Agent"); ua != userAgentDefault {
+		t.Errorf("Context headers error: unexpected User-Agent `%s`", ua)
+	}
+
+	if tr := header.Get("X-Trace-Id"); tr != "testTraceID" {
+		t.Errorf("Context headers error: unexpected X-Trace-Id `%s`", tr)
+	}
+
+	if ct := header.Get("Content-Type"); ct != "application/json-rpc" {
+		t.Errorf("Context headers error: unexpected Content-Type `%s`", ct)
+	}
+
+	// Caller's User-Agent overrides default one
+	z.Headers.Set("User-Agent", "testUserAgent")
+
+	if _, _, err := z.HostgroupGet(HostgroupGetParams{}); err != nil {
+		t.Fatal("Context headers error:", err)
+	}
+
+	if ua := header.Get("User-Agent"); ua != "testUserAgent" {
+		t.Errorf("Context headers error: unexpected User-Agent `%s`", ua)
+	}
+
+	t.Logf("Context headers: success")
+}
+
+func TestContextPing(t *testing.T) {
+
+	authValid := true
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		switch method {
+		case "apiinfo.version":
+			return "5.0.0", nil
+		case "user.get":
+			if authValid == false {
+				return nil, errors.New("Session terminated, re-login, please.")
+			}
+			return []map[string]interface{}{{"userid": "1"}}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+
+	if err := z.Ping(); err != nil {
+		t.Fatal("Context ping error:", err)
+	}
+
+	// Auth failed
+	authValid = false
+
+	if err := z.Ping(); errors.Is(err, ErrPingAuthFailed) == false {
+		t.Errorf("Context ping error: expected auth failed error, got %v", err)
+	}
+
+	// Unreachable
+	closeMock()
+
+	if err := z.Ping(); errors.Is(err, ErrPingUnreachable) == false {
+		t.Errorf("Context ping error: expected unreachable error, got %v", err)
+	}
+
+	t.Logf("Context ping: success")
+}
+
+func TestContextInvalidResponse(t *testing.T) {
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "html",
+			body: "<html><head><title>502 Bad Gateway</title></head><body>502 Bad Gateway</body></html>",
+		},
+		{
+			name: "no jsonrpc",
+			body: `{"result":"5.0.0","id":1}`,
+		},
+		{
+			name: "no result",
+			body: `{"jsonrpc":"2.0","id":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(tt.body))
+		}))
+
+		z := Context{
+			host: srv.URL,
+		}
+
+		_, _, err := z.APIInfoVersion()
+		srv.Close()
+
+		if errors.Is(err, ErrInvalidResponse) == false {
+			t.Errorf("Context invalid response error (%s): expected invalid response error, got %v", tt.name, err)
+		}
+	}
+
+	t.Logf("Context invalid response: success")
+}
+
+func TestContextTransportError(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("Service Unavailable"))
+	}))
+
+	z := Context{
+		host: srv.URL,
+	}
+
+	_, _, err := z.APIInfoVersion()
+	srv.Close()
+
+	var te *TransportError
+	if errors.As(err, &te) == false {
+		t.Fatalf("Context transport error: expected transport error, got %v", err)
+	}
+
+	if te.StatusCode != http.StatusServiceUnavailable || te.Body != "Service Unavailable" {
+		t.Errorf("Context transport error: unexpected error %+v", te)
+	}
+
+	var ze *ZabbixError
+	if errors.As(err, &ze) == true {
+		t.Error("Context transport error: unexpected Zabbix error")
+	}
+
+	// Connection failure (server is closed)
+	_, _, err = z.APIInfoVersion()
+	if errors.As(err, &te) == false || te.Err == nil || te.StatusCode != 0 {
+		t.Errorf("Context transport error (connection): expected transport error, got %v", err)
+	}
+
+	// Zabbix API error
+	mz, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+		return nil, errors.New("No permissions to referred object or it does not exist!")
+	})
+	defer closeMock()
+
+	_, _, err = mz.HostGet(HostGetParams{})
+	if errors.As(err, &ze) == false || ze.Code != -32602 {
+		t.Errorf("Context transport error (api): expected Zabbix error, got %v", err)
+	}
+
+	if errors.As(err, &te) == true {
+		t.Error("Context transport error (api): unexpected transport error")
+	}
+
+	t.Logf("Context transport error: success")
+}
+
+func TestContextLenientSelectors(t *testing.T) {
+
+	var (
+		requests []json.RawMessage
+		rejected []string
+	)
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		var p map[string]interface{}
+
+		if method != "host.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		requests = append(requests, params)
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		if _, b := p["selectInheritedTags"]; b == true {
+			return nil, errors.New(`Invalid parameter "/": unexpected parameter "selectInheritedTags".`)
+		}
+
+		return []map[string]interface{}{{"hostid": "10084", "tags": []interface{}{}}}, nil
+	})
+	defer closeMock()
+
+	params := HostGetParams{
+		HostIDs:             []int{10084},
+		SelectTags:          SelectExtendedOutput,
+		SelectInheritedTags: SelectExtendedOutput,
+	}
+
+	// Strict mode
+	if _, _, err := z.HostGet(params); err == nil {
+		t.Error("Context lenient selectors error: expected error in strict mode")
+	}
+
+	if len(requests) != 1 {
+		t.Errorf("Context lenient selectors error: unexpected retry in strict mode")
+	}
+
+	// Lenient mode
+	requests = nil
+
+	z.LenientSelectors = true
+	z.OnSelectorRejected = func(method, selector string) {
+		rejected = append(rejected, method+": "+selector)
+	}
+
+	hObjects, _, err := z.HostGet(params)
+	if err != nil {
+		t.Fatal("Context lenient selectors error:", err)
+	}
+
+	if len(hObjects) != 1 || hObjects[0].HostID != 10084 {
+		t.Errorf("Context lenient selectors error: unexpected hosts %v", hObjects)
+	}
+
+	if len(requests) != 2 || string(requests[1]) != `{"hostids":[10084],"selectTags":"extend"}` {
+		t.Errorf("Context lenient selectors error: unexpected requests %s", requests)
+	}
+
+	if reflect.DeepEqual(rejected, []string{"host.get: selectInheritedTags"}) == false {
+		t.Errorf("Context lenient selectors error: unexpected rejected selectors %v", rejected)
+	}
+
+	t.Logf("Context lenient selectors: success")
+}
+
+func TestContextSessionKeepalive(t *testing.T) {
+
+	var (
+		mu     sync.Mutex
+		checks int
+		logins int
+	)
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch method {
+		case "user.checkAuthentication":
+
+			var p struct {
+				SessionID string `json:"sessionid"`
+			}
+
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+
+			checks++
+
+			// Session is expired on the first check
+			if p.SessionID == "mockSessionKey" {
+				return nil, errors.New("Session terminated, re-login, please.")
+			}
+
+			return map[string]interface{}{"userid": "1", "sessionid": p.SessionID}, nil
+		case "user.login":
+
+			logins++
+
+			return "renewedSessionKey", nil
+		}
+
+		return nil, fmt.Errorf("unexpected method %s", method)
+	})
+	defer closeMock()
+
+	// Session mutex is not created on login for mock context
+	z.user = "Admin"
+	z.password = "zabbix"
+
+	count := func() (int, int) {
+		mu.Lock()
+		defer mu.Unlock()
+		return checks, logins
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	z.StartSessionKeepalive(ctx, 10*time.Millisecond)
+
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		// Session is read concurrently with keepalive
+		z.session()
+		if c, _ := count(); c >= 3 {
+			break
+		}
+	}
+
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	c, l := count()
+	if c < 3 || l != 1 {
+		t.Errorf("Context session keepalive error: unexpected checks %d and logins %d", c, l)
+	}
+
+	if s := z.session(); s != "renewedSessionKey" {
+		t.Errorf("Context session keepalive error: unexpected session key %s", s)
+	}
+
+	// Stopped on cancel
+	time.Sleep(50 * time.Millisecond)
+
+	if n, _ := count(); n != c {
+		t.Errorf("Context session keepalive error: checks continue after cancel (%d, %d)", c, n)
+	}
+
+	t.Logf("Context session keepalive: success")
+}
+
+func TestContextRequestMarshal(t *testing.T) {
+
+	var bodies []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error("Mock server error:", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		bodies = append(bodies, string(b))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"jsonrpc":"2.0","result":[],"id":1}`)
+	}))
+	defer srv.Close()
+
+	z := Context{
+		host:       srv.URL,
+		sessionKey: "mockSessionKey",
+	}
+
+	params := func() HostGetParams {
+		return HostGetParams{
+			GroupIDs: []int{2, 4},
+			GetParameters: GetParameters{
+				Filter: map[string]interface{}{
+					"status": 0,
+					"host":   "server",
+					"flags":  []int{0, 4},
+				},
+				Output: SelectFields{"hostid", "host"},
+			},
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := z.HostGet(params()); err != nil {
+			t.Fatal("Context request marshal error:", err)
+		}
+	}
+
+	if len(bodies) != 2 || bodies[0] != bodies[1] {
+		t.Fatalf("Context request marshal error: bodies differ: %v", bodies)
+	}
+
+	expected := `{"auth":"mockSessionKey","id":1,"jsonrpc":"2.0","method":"host.get","params":{"filter":{"flags":[0,4],"host":"server","status":0},"groupids":[2,4],"output":["hostid","host"]}}`
+	if bodies[0] != expected {
+		t.Errorf("Context request marshal error: unexpected body %s", bodies[0])
+	}
+
+	t.Logf("Context request marshal: success")
+}
+
+func TestContextSelectFields(t *testing.T) {
+
+	params := []interface{}{
+		&ActionGetParams{},
+		&DashboardGetParams{},
+		&DiscoveryruleGetParams{},
+		&EventGetParams{},
+		&GraphGetParams{},
+		&HostGetParams{},
+		&HostgroupGetParams{},
+		&HostinterfaceGetParams{},
+		&ItemGetParams{},
+		&ItemprototypeGetParams{},
+		&MaintenanceGetParams{},
+		&MediatypeGetParams{},
+		&ProblemGetParams{},
+		&ProxyGetParams{},
+		&ServiceGetParams{},
+		&TemplateGetParams{},
+		&TriggerGetParams{},
+		&UserGetParams{},
+		&UsergroupGetParams{},
+		&UsermacroGetParams{},
+		&ValuemapGetParams{},
+	}
+
+	fields := SelectFields{"hostid", "name"}
+
+	for _, p := range params {
+
+		v := reflect.ValueOf(p).Elem()
+
+		var selectors []string
+
+		for i := 0; i < v.NumField(); i++ {
+
+			f := v.Type().Field(i)
+
+			if strings.HasPrefix(f.Name, "Select") == false {
+				continue
+			}
+
+			v.Field(i).Set(reflect.ValueOf(fields))
+			selectors = append(selectors, strings.Split(f.Tag.Get("json"), ",")[0])
+		}
+
+		b, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("Context select fields error (%s): %v", v.Type().Name(), err)
+		}
+
+		var m map[string]interface{}
+		if err := json.Unmarshal(b, &m); err != nil {
+			t.Fatalf("Context select fields error (%s): %v", v.Type().Name(), err)
+		}
+
+		for _, s := range selectors {
+			if reflect.DeepEqual(m[s], []interface{}{"hostid", "name"}) == false {
+				t.Errorf("Context select fields error (%s): unexpected `%s` value %v", v.Type().Name(), s, m[s])
+			}
+		}
+	}
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		if method != "item.get" {
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}
+
+		var p struct {
+			SelectHosts []string `json:"selectHosts"`
+		}
+
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		hosts := []map[string]interface{}{}
+		for _, h := range []map[string]interface{}{{"hostid": "10084", "host": "server", "name": "Server", "status": "0"}} {
+			r := make(map[string]interface{})
+			for _, f := range p.SelectHosts {
+				r[f] = h[f]
+			}
+			hosts = append(hosts, r)
+		}
+
+		return []map[string]interface{}{{"itemid": "1001", "hosts": hosts}}, nil
+	})
+	defer closeMock()
+
+	iObjects, _, err := z.ItemGet(ItemGetParams{
+		SelectHosts: fields,
+		GetParameters: GetParameters{
+			Output: SelectFields{"itemid"},
+		},
+	})
+	if err != nil {
+		t.Fatal("Context select fields error:", err)
+	}
+
+	if len(iObjects) != 1 || len(iObjects[0].Hosts) != 1 {
+		t.Fatalf("Context select fields error: unexpected items %v", iObjects)
+	}
+
+	h := iObjects[0].Hosts[0]
+	if h.HostID != 10084 || h.Name != "Server" || h.Host != "" {
+		t.Errorf("Context select fields error: unexpected host %v", h)
+	}
+
+	t.Logf("Context select fields: success")
+}
+
+func TestContextWithReconnect(t *testing.T) {
+
+	var (
+		mu      sync.Mutex
+		drops   = 2
+		logins  int
+		creates int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		var req struct {
+			Method string `json:"method"`
+			Auth   string `json:"auth"`
+			ID     int    `json:"id"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error("Mock server error:", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resp := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+		}
+
+		switch req.Method {
+		case "user.login":
+			logins++
+			resp["result"] = fmt.Sprintf("session%d", logins)
+		case "user.get":
+			// Drop connection to emulate network failure
+			if drops > 0 {
+				drops--
+				c, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					t.Error("Mock server error:", err)
+					return
+				}
+				c.Close()
+				return
+			}
+			if req.Auth != "session1" {
+				resp["error"] = map[string]interface{}{
+					"code":    -32602,
+					"message": "Invalid params.",
+					"data":    "Session terminated, re-login, please.",
+				}
+			} else {
+				resp["result"] = []map[string]interface{}{{"userid": "1"}}
+			}
+		case "item.create":
+			// Connection is dropped after the request is processed
+			mu.Lock()
+			creates++
+			mu.Unlock()
+			c, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Error("Mock server error:", err)
+				return
+			}
+			c.Close()
+			return
+		}
+
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	z := Context{
+		host:       srv.URL,
+		sessionKey: "expiredSession",
+		user:       "Admin",
+		password:   "zabbix",
+	}
+
+	// Without reconnects
+	if _, _, err := z.UserGet(UserGetParams{}); err == nil {
+		t.Fatal("Context with reconnect error: expected connection error")
+	}
+
+	// With reconnects
+	zr := z.WithReconnect(10 * time.Millisecond)
+
+	uObjects, _, err := zr.UserGet(UserGetParams{})
+	if err != nil {
+		t.Fatal("Context with reconnect error:", err)
+	}
+
+	if len(uObjects) != 1 || logins != 1 {
+		t.Errorf("Context with reconnect error: unexpected result %v (logins: %d)", uObjects, logins)
+	}
+
+	if z.sessionKey != "expiredSession" || zr.sessionKey != "session1" {
+		t.Errorf("Context with reconnect error: unexpected session keys %s and %s", z.sessionKey, zr.sessionKey)
+	}
+
+	// Non read-only requests are not repeated
+	if _, _, err := zr.ItemCreate([]ItemCreateParams{{HostID: 10001, Name: "Test item", Key: "test.item"}}); err == nil {
+		t.Error("Context with reconnect error: expected connection error for item create")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if creates != 1 {
+		t.Errorf("Context with reconnect error: expected 1 item create request, got %d", creates)
+	}
+
+	t.Logf("Context with reconnect: success")
+}
+
+func TestContextInsecureSkipVerify(t *testing.T) {
+
+	var z Context
+
+	for _, skip := range []bool{true, false} {
+
+		z.SetInsecureSkipVerify(skip)
+
+		tr, b := z.httpClient().Transport.(*http.Transport)
+		if b == false {
+			t.Fatal("Context insecure skip verify error: unexpected transport type")
+		}
+
+		if tr.TLSClientConfig == nil || tr.TLSClientConfig.InsecureSkipVerify != skip {
+			t.Errorf("Context insecure skip verify error: TLS config does not reflect setting %v", skip)
+		}
+	}
+
+	// Default transport must not be affected
+	if tr := http.DefaultTransport.(*http.Transport); tr.TLSClientConfig != nil && tr.TLSClientConfig.InsecureSkipVerify == true {
+		t.Error("Context insecure skip verify error: default transport is affected")
+	}
+
+	// Custom client must not be affected
+	custom := &http.Client{
+		Transport: &http.Transport{},
+	}
+	z.HTTPClient = custom
+
+	z.SetInsecureSkipVerify(true)
+
+	if z.httpClient() != custom || custom.Transport.(*http.Transport).TLSClientConfig != nil {
+		t.Error("Context insecure skip verify error: custom client is affected")
+	}
+
+	t.Logf("Context insecure skip verify: success")
+}
+
+func TestContextMassUpdate(t *testing.T) {
+
+	var (
+		sentMethod string
+		sent       map[string]interface{}
+	)
+
+	z, closeMock := testMockContext(t, func(method string, params json.RawMessage) (interface{}, error) {
+
+		sentMethod = method
+
+		if err := json.Unmarshal(params, &sent); err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{"hostids": []string{"10084", "10085"}}, nil
+	})
+	defer closeMock()
+
+	ids, err := z.MassUpdate("host", []int{10084, 10085}, map[string]interface{}{
+		"status": HostStatusUnmonitored,
+	})
+	if err != nil {
+		t.Fatal("Context mass update error:", err)
+	}
+
+	if sentMethod != "host.massupdate" {
+		t.Errorf("Context mass update error: unexpected method %s", sentMethod)
+	}
+
+	b, _ := json.Marshal(sent)
+	if string(b) != `{"hosts":[{"hostid":10084},{"hostid":10085}],"status":1}` {
+		t.Errorf("Context mass update error: unexpected params %s", string(b))
+	}
+
+	if reflect.DeepEqual(ids, []int{10084, 10085}) == false {
+		t.Errorf("Context mass update error: unexpected result %v", ids)
+	}
+
+	// Unknown entity
+	if _, err := z.MassUpdate("item", []int{1}, map[string]interface{}{"status": 1}); err == nil {
+		t.Error("Context mass update error: expected error for unsupported entity")
+	}
+
+	t.Logf("Context mass update: success")
+}